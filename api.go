@@ -7,6 +7,7 @@ package cligo
 
 import (
 	"context"
+	"io"
 	"reflect"
 
 	"go.arpabet.com/glue"
@@ -40,6 +41,20 @@ type CliCommandWithBeans interface {
 	CommandBeans() []interface{}
 }
 
+// CliCompleter is an optional interface a CliCommand can implement to provide
+// dynamic shell completion candidates, e.g. values resolved from beans at runtime.
+type CliCompleter interface {
+	// Complete returns candidates for the argument or option named argName, given the partially typed value
+	Complete(ctx context.Context, argName string, partial string) []string
+}
+
+// CliValidator is an optional interface a CliCommand can implement to check rules across
+// its arguments and options that tags cannot express, e.g. --from must be before --to.
+type CliValidator interface {
+	// Validate is called after arguments and options are bound and before Run; an error is reported as a usage error
+	Validate(ctx context.Context) error
+}
+
 var CliApplicationClass = reflect.TypeOf((*CliApplication)(nil)).Elem()
 
 type CliApplication interface {
@@ -50,6 +65,21 @@ type CliApplication interface {
 	Build() string
 	Verbose() bool
 
+	// Args returns the command-line arguments, without the program name
+	Args() []string
+
+	// Stdout returns the writer for regular output, help and usage
+	Stdout() io.Writer
+
+	// Stderr returns the writer for error output
+	Stderr() io.Writer
+
+	// Stdin returns the reader for input
+	Stdin() io.Reader
+
+	// Echo prints a formatted line to Stdout
+	Echo(format string, args ...interface{})
+
 	// Non-public method to keep optional beans private
 	getBeans() []interface{}
 
@@ -2,6 +2,7 @@ package cligo
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,12 +19,21 @@ type implCliApplication struct {
 	build         string
 	verbose       bool
 	color         *bool
+	args          []string
+	stdout        io.Writer
+	stderr        io.Writer
+	stdin         io.Reader
 	configFiles   []string
+	cliConfigs    []string
+	mergeConfig   bool
+	discovery     bool
 	profiles      []string
 	cliProperties map[string]string
+	globalFlags   globalFlags
 	ctx           context.Context
 	beans         []interface{}
 	properties    glue.Properties
+	allProperties glue.Properties
 	groups        map[string][]CliGroup
 	commands      map[string][]CliCommand
 	commandBeans  map[string][]interface{}
@@ -45,6 +55,7 @@ func New(options ...Option) CliApplication {
 		aliasOf:      make(map[interface{}]string),
 		cmdAliases:   make(map[string]map[string]CliCommand),
 		groupAliases: make(map[string]map[string]CliGroup),
+		globalFlags:  defaultGlobalFlags(),
 	}
 
 	// first bean is application itself
@@ -59,6 +70,19 @@ func New(options ...Option) CliApplication {
 		app.name = filepath.Base(os.Args[0])
 	}
 
+	if app.args == nil {
+		app.args = os.Args[1:]
+	}
+	if app.stdout == nil {
+		app.stdout = os.Stdout
+	}
+	if app.stderr == nil {
+		app.stderr = os.Stderr
+	}
+	if app.stdin == nil {
+		app.stdin = os.Stdin
+	}
+
 	var str strings.Builder
 	if app.title != "" {
 		str.WriteString(app.title)
@@ -71,21 +95,28 @@ func New(options ...Option) CliApplication {
 	app.helps[RootGroup] = str.String()
 
 	if !app.verbose {
-		app.verbose = hasVerbose(os.Args[1:])
+		app.verbose = hasVerbose(app.args)
 	}
 
+	// Global flags are only recognised before the command path, see globalFlags.split
+	globalArgs, _ := app.globalFlags.split(app.args)
+
 	// Merge CLI --profile/-p flag values with programmatic profiles
-	if cliProfiles := parseGlobalFlag(os.Args[1:], "profile", "p"); len(cliProfiles) > 0 {
+	if cliProfiles := parseGlobalFlag(globalArgs, app.globalFlags.profile); len(cliProfiles) > 0 {
 		app.profiles = append(app.profiles, cliProfiles...)
 	}
 
 	// Merge CLI --config/-c flag values with programmatic config files
-	if cliConfigs := parseGlobalFlag(os.Args[1:], "config", "c"); len(cliConfigs) > 0 {
-		app.configFiles = append(app.configFiles, cliConfigs...)
-	}
+	app.cliConfigs = parseGlobalFlag(globalArgs, app.globalFlags.config)
 
 	// Collect CLI -D/--property key=value overrides (highest-priority properties)
-	if cliProps := parseGlobalProperties(os.Args[1:]); len(cliProps) > 0 {
+	cliProps := parseGlobalProperties(globalArgs, app.globalFlags.property)
+
+	// Global options feed the same overrides; a global option wins over -D for its property
+	for key, value := range app.globalFlags.optionProperties(globalArgs) {
+		cliProps[key] = value
+	}
+	if len(cliProps) > 0 {
 		app.cliProperties = cliProps
 	}
 
@@ -116,6 +147,27 @@ func (t *implCliApplication) Verbose() bool {
 	return t.verbose
 }
 
+func (t *implCliApplication) Args() []string {
+	return t.args
+}
+
+func (t *implCliApplication) Stdout() io.Writer {
+	return t.stdout
+}
+
+func (t *implCliApplication) Stderr() io.Writer {
+	return t.stderr
+}
+
+func (t *implCliApplication) Stdin() io.Reader {
+	return t.stdin
+}
+
+// Echo prints a formatted line to the application stdout. With an empty format string, it prints a blank line.
+func (t *implCliApplication) Echo(format string, args ...interface{}) {
+	echo(t.stdout, format, args...)
+}
+
 func (t *implCliApplication) getBeans() []interface{} {
 	return t.beans
 }
@@ -128,8 +180,9 @@ func (t *implCliApplication) getContext() context.Context {
 	return t.ctx
 }
 
+// getConfigFiles returns the ConfigFile paths followed by the --config values.
 func (t *implCliApplication) getConfigFiles() []string {
-	return t.configFiles
+	return append(append([]string(nil), t.configFiles...), t.cliConfigs...)
 }
 
 func (t *implCliApplication) getProfiles() []string {
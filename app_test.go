@@ -5,7 +5,11 @@
 
 package cligo
 
-import "testing"
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
 
 // ─── Echo ────────────────────────────────────────────────────────────────────
 
@@ -106,6 +110,66 @@ func TestNew_BeansStored(t *testing.T) {
 	})
 }
 
+// ─── Args / Stdout / Stderr / Stdin ─────────────────────────────────────────
+
+func TestNew_ArgsOption(t *testing.T) {
+	app := New(Args([]string{"--verbose", "ship"}))
+	if got := app.Args(); len(got) != 2 || got[0] != "--verbose" {
+		t.Errorf("expected args [--verbose ship], got %v", got)
+	}
+	if !app.Verbose() {
+		t.Error("expected Verbose=true from Args option")
+	}
+}
+
+func TestNew_StdioOptions(t *testing.T) {
+	var out, errOut bytes.Buffer
+	in := strings.NewReader("input")
+	app := New(Args(nil), Stdout(&out), Stderr(&errOut), Stdin(in))
+	if app.Stdout() != &out || app.Stderr() != &errOut || app.Stdin() != in {
+		t.Error("expected configured stdout, stderr and stdin")
+	}
+	app.Echo("hello %s", "world")
+	if out.String() != "hello world\n" {
+		t.Errorf("expected 'hello world\\n', got %q", out.String())
+	}
+}
+
+func TestRun_ArgsAndStdout_Parallel(t *testing.T) {
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			cmd := &newShipCmd{}
+			if err := Run(Args([]string{"ship", "new", name}), Stdout(&out), Beans(&shipGroup{}, cmd)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cmd.Name != name {
+				t.Errorf("expected Name=%s, got %q", name, cmd.Name)
+			}
+			out.Reset()
+			if err := Run(Name("app"), Args([]string{"ship", "new", "--help"}), Stdout(&out), Beans(&shipGroup{}, &newShipCmd{})); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(out.String(), "Usage: app ship [OPTIONS] NAME") {
+				t.Errorf("expected command help in configured stdout, got: %q", out.String())
+			}
+		})
+	}
+}
+
+func TestRun_UsageError_WrittenToStdout(t *testing.T) {
+	var out bytes.Buffer
+	err := Run(Name("app"), Args([]string{"ship", "new"}), Stdout(&out), Beans(&shipGroup{}, &newShipCmd{}))
+	if err == nil {
+		t.Fatal("expected error for missing argument")
+	}
+	if !strings.Contains(out.String(), "Try 'app ship new --help' for help") {
+		t.Errorf("expected usage in configured stdout, got: %q", out.String())
+	}
+}
+
 // ─── Register* ───────────────────────────────────────────────────────────────
 
 func TestRegisterGroup_Valid(t *testing.T) {
@@ -163,3 +227,27 @@ func TestRegisterCommandWithBeans_NoParent(t *testing.T) {
 		}
 	})
 }
+
+func TestRegisterCommand_MalformedTag(t *testing.T) {
+	app := New(Args(nil))
+	err := app.RegisterCommand(&badTagCmd{})
+	if err == nil {
+		t.Fatal("expected error for malformed cli tag")
+	}
+	if !strings.Contains(err.Error(), "badTagCmd.Speed") {
+		t.Errorf("expected struct and field in error, got: %v", err)
+	}
+}
+
+func TestRun_QuotedTagValues(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"quoted", "--help"}), Stdout(&out), Beans(&quotedTagCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Speed, in knots [default: 10]") {
+		t.Errorf("expected help with comma, got: %q", out.String())
+	}
+	if !strings.Contains(out.String(), "Tags, comma-separated in env") {
+		t.Errorf("expected escaped comma in help, got: %q", out.String())
+	}
+}
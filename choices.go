@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import "strings"
+
+// parseChoices splits the choices= tag (json|yaml|table) into the allowed values, or returns nil without the tag.
+func parseChoices(tagParts map[string]string) []string {
+	val, ok := tagParts["choices"]
+	if !ok {
+		return nil
+	}
+	return strings.Split(val, "|")
+}
+
+// choiceError reports a value that is not one of choices as a usage error, suggesting the
+// closest allowed value. It returns nil when the value is allowed or there are no choices.
+// The subject names what was given, e.g. "option --format from $APP_FORMAT".
+func choiceError(subject string, value string, choices []string) error {
+	if len(choices) == 0 {
+		return nil
+	}
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	expected := strings.Join(choices, "|")
+	if suggestion := closest(value, choices); suggestion != "" {
+		return usageErrorf("invalid choice for %s: %s (expected %s). Did you mean %q?", subject, value, expected, suggestion)
+	}
+	return usageErrorf("invalid choice for %s: %s (expected %s)", subject, value, expected)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// ─── choices ─────────────────────────────────────────────────────────────────
+
+func TestChoices_Accepted(t *testing.T) {
+	cmd := &choicesCmd{}
+	if err := Run(Args([]string{"export", "safe", "--format=yaml", "--level=1,3"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Mode != "safe" || cmd.Format != "yaml" || len(cmd.Level) != 2 {
+		t.Errorf("expected safe yaml [1 3], got %q %q %v", cmd.Mode, cmd.Format, cmd.Level)
+	}
+
+	cmd = &choicesCmd{}
+	if err := Run(Args([]string{"export", "fast"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Format != "table" {
+		t.Errorf("expected default Format=table, got %q", cmd.Format)
+	}
+}
+
+func TestChoices_Rejected(t *testing.T) {
+	t.Setenv("CHOICES_FORMAT", "")
+	for _, tc := range []struct {
+		args []string
+		env  string
+		want string
+	}{
+		{[]string{"export", "slow"}, "", `invalid choice for argument mode: slow (expected fast|safe)`},
+		{[]string{"export", "fast", "--format=jsn"}, "", `invalid choice for option --format: jsn (expected json|yaml|table). Did you mean "json"?`},
+		{[]string{"export", "fast"}, "xml", `invalid choice for option --format from $CHOICES_FORMAT: xml (expected json|yaml|table)`},
+		{[]string{"export", "fast", "--level=2,4"}, "", `invalid choice for option --level: 4 (expected 1|2|3)`},
+	} {
+		t.Setenv("CHOICES_FORMAT", tc.env)
+		cmd := &choicesCmd{}
+		err := Run(Args(tc.args), Stdout(&bytes.Buffer{}), Beans(cmd))
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%v: expected usage error, got: %v", tc.args, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: expected %q in error, got: %v", tc.args, tc.want, err)
+		}
+		if cmd.ran {
+			t.Errorf("%v: command should not run", tc.args)
+		}
+	}
+}
+
+func TestChoices_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"export", "--help"}), Stdout(&out), Beans(&choicesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"mode argument [fast|safe] [required]", "Output format [json|yaml|table] [default: table]"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in help, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestChoices_Complete(t *testing.T) {
+	complete := func(words ...string) string {
+		var out bytes.Buffer
+		if err := Run(Args(append([]string{"__complete"}, words...)), Stdout(&out), Beans(&choicesCmd{})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return strings.Join(strings.Fields(out.String()), " ")
+	}
+	if got := complete("export", ""); got != "fast safe" {
+		t.Errorf("expected [fast safe], got %q", got)
+	}
+	if got := complete("export", "fast", "--format", "y"); got != "yaml" {
+		t.Errorf("expected [yaml], got %q", got)
+	}
+	if got := complete("export", "--format="); got != "--format=json --format=yaml --format=table" {
+		t.Errorf("expected assigned choices, got %q", got)
+	}
+}
+
+func TestChoices_CompletionScript(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Name("app"), Args([]string{"completion", "bash"}), Stdout(&out), Beans(&choicesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "__complete") {
+		t.Errorf("expected dynamic completion for a command with choices, got:\n%s", out.String())
+	}
+}
+
+func TestValidateCliTags_Choices(t *testing.T) {
+	type badChoicesCmd struct {
+		Level  int    `cli:"option=level,choices=1|two"`
+		Format string `cli:"option=format,default=xml,choices=json||yaml"`
+	}
+	err := validateCliTags(&badChoicesCmd{})
+	if err == nil {
+		t.Fatal("expected error for choices tags")
+	}
+	for _, want := range []string{
+		`badChoicesCmd.Level: invalid choice "two" for int`,
+		`badChoicesCmd.Format: empty value in choices "json||yaml"`,
+		`badChoicesCmd.Format: default "xml" is not one of the choices "json||yaml"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
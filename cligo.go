@@ -10,11 +10,14 @@ package cligo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 	"reflect"
+	"sync/atomic"
 	"syscall"
 
 	"go.arpabet.com/glue"
@@ -31,18 +34,29 @@ type parentInfo struct {
 }
 
 // Echo prints a formatted line to stdout. With an empty format string, it prints a blank line.
+// Use CliApplication.Echo to print to the writer configured with the Stdout option.
 func Echo(format string, args ...interface{}) {
+	echo(os.Stdout, format, args...)
+}
+
+func echo(w io.Writer, format string, args ...interface{}) {
 	if len(format) == 0 {
-		println()
+		fmt.Fprintln(w)
 		return
 	}
-	fmt.Printf(format+"\n", args...)
+	fmt.Fprintf(w, format+"\n", args...)
 }
 
 // Run creates the application, sets up the glue DI container, discovers all
-// registered groups and commands, then parses os.Args and executes the matched command.
+// registered groups and commands, then parses the arguments (os.Args unless the Args
+// option is given) and executes the matched command.
 // Returns an error on failure. Panics from command execution are recovered and returned as errors.
-func Run(options ...Option) (err error) {
+func Run(options ...Option) error {
+	return New(options...).(*implCliApplication).run()
+}
+
+// run executes the application created by New.
+func (t *implCliApplication) run() (err error) {
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -57,48 +71,59 @@ func Run(options ...Option) (err error) {
 		}
 	}()
 
-	app := New(options...)
+	if err := t.globalFlags.validate(t.version != ""); err != nil {
+		return err
+	}
 
 	var beans []any
 
 	// Resolve config files into glue PropertySource beans
-	configFiles := app.getConfigFiles()
-	if len(configFiles) > 0 {
-		configBeans, err := resolveConfigFiles(configFiles)
+	paths, err := t.configPaths()
+	if err != nil {
+		return err
+	}
+	if len(paths)+len(t.cliConfigs) > 0 {
+		configBeans, err := resolveConfigFiles(paths, t.cliConfigs, t.mergeConfig)
 		if err != nil {
 			return err
 		}
 		beans = configBeans
 	}
 
-	beans = append(beans, app.getBeans()...)
+	beans = append(beans, t.getBeans()...)
 
 	// Register command-line -D/--property overrides as a top-priority property
 	// resolver so they win over env vars, config files and in-code defaults.
-	if cliProps := app.getCliProperties(); len(cliProps) > 0 {
+	if cliProps := t.getCliProperties(); len(cliProps) > 0 {
 		beans = append(beans, &cliPropertyResolver{props: cliProps})
 	}
 
+	// Defaults of global options only apply when no other source sets their property.
+	if defaults := t.globalFlags.optionDefaults(); len(defaults) > 0 {
+		beans = append(beans, &cliDefaultResolver{cliPropertyResolver{props: defaults}})
+	}
+
 	// Use user-provided context or create a signal-aware one
-	ctx := app.getContext()
+	var received atomic.Value
+	ctx := t.getContext()
 	if ctx == nil {
 		var cancel context.CancelFunc
-		ctx, cancel = signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		ctx, cancel = signalContext(&received)
 		defer cancel()
 	}
 
 	glueOpts := []glue.ContainerOption{glue.WithContext(ctx)}
 
-	if profiles := app.getProfiles(); len(profiles) > 0 {
+	if profiles := t.getProfiles(); len(profiles) > 0 {
 		glueOpts = append(glueOpts, glue.WithProfiles(profiles...))
 	}
 
-	if hasVerbose(os.Args[1:]) {
-		glueOpts = append(glueOpts, glue.WithLogger(log.Default()))
+	if hasVerbose(t.args) {
+		glueOpts = append(glueOpts, glue.WithLogger(log.New(t.stderr, "", log.LstdFlags)))
 	}
 
-	if app.getProperties() != nil {
-		glueOpts = append(glueOpts, glue.WithProperties(app.getProperties()))
+	if t.getProperties() != nil {
+		glueOpts = append(glueOpts, glue.WithProperties(t.getProperties()))
 	}
 
 	glueOpts = append(glueOpts, glue.WithBeans(beans...))
@@ -109,6 +134,9 @@ func Run(options ...Option) (err error) {
 	}
 	defer c.Close()
 
+	// Placeholders in default= and help= are expanded against these, see expandPlaceholders
+	t.allProperties = c.Properties()
+
 	visited := make(map[uintptr]bool)
 
 	// Register all groups
@@ -119,7 +147,7 @@ func Run(options ...Option) (err error) {
 			continue
 		}
 		visited[addr] = true
-		err = app.RegisterGroup(obj.(CliGroup))
+		err = t.RegisterGroup(obj.(CliGroup))
 		if err != nil {
 			return err
 		}
@@ -133,7 +161,7 @@ func Run(options ...Option) (err error) {
 			continue
 		}
 		visited[addr] = true
-		err = app.RegisterCommandWithBeans(obj.(CliCommandWithBeans))
+		err = t.RegisterCommandWithBeans(obj.(CliCommandWithBeans))
 		if err != nil {
 			return err
 		}
@@ -147,27 +175,65 @@ func Run(options ...Option) (err error) {
 			continue
 		}
 		visited[addr] = true
-		err = app.RegisterCommand(obj.(CliCommand))
+		err = t.RegisterCommand(obj.(CliCommand))
 		if err != nil {
 			return err
 		}
 	}
 
-	return app.Execute(ctx, c)
+	return signalExitError(t.Execute(ctx, c), &received)
+}
+
+// signalExitError wraps err in an ExitError with the conventional 128+N exit code when a received
+// signal cancelled the context. An ExitError returned by the command takes precedence and keeps its code.
+func signalExitError(err error, received *atomic.Value) error {
+	sig, ok := received.Load().(syscall.Signal)
+	if !ok || err == nil {
+		return err
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return err
+	}
+	return &ExitError{Code: 128 + int(sig), Err: err}
+}
+
+// signalContext returns a context cancelled on SIGINT or SIGTERM and stores the received signal.
+func signalContext(received *atomic.Value) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			received.Store(sig)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
 }
 
 // Main is the standard entry point for CLI applications.
-// It calls Run and prints the error to stdout and exits with code 1 on failure.
+// It calls Run, prints the error to stderr and exits with the code chosen by ExitCode:
+// the code of an ExitError returned by the command, 128+N when a signal cancelled the context,
+// 2 for usage errors, 1 otherwise.
 func Main(options ...Option) {
 
-	if err := Run(options...); err != nil {
-		// Detect color for error output without needing the app instance
-		errPrefix := "Error"
-		fi, statErr := os.Stderr.Stat()
-		if statErr == nil && fi.Mode()&os.ModeCharDevice != 0 && os.Getenv("NO_COLOR") == "" {
-			errPrefix = ansiRed + ansiBold + "Error" + ansiReset
+	app := New(options...).(*implCliApplication)
+	if err := app.run(); err != nil {
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Err != nil {
+			// Color the error prefix only when stderr is a terminal
+			errPrefix := "Error"
+			if isTerminal(app.stderr) && os.Getenv("NO_COLOR") == "" {
+				errPrefix = ansiRed + ansiBold + "Error" + ansiReset
+			}
+			fmt.Fprintf(app.stderr, "%s: %v\n", errPrefix, err)
 		}
-		fmt.Fprintf(os.Stderr, "%s: %v\n", errPrefix, err)
-		os.Exit(1)
+		os.Exit(ExitCode(err))
 	}
 }
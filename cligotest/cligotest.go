@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+// Package cligotest provides an in-memory harness for testing cligo applications.
+// It runs an application against explicit arguments, captures stdout and stderr,
+// and offers golden-file helpers for snapshotting help output.
+package cligotest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.arpabet.com/cligo"
+)
+
+var update = flag.Bool("cligotest.update", false, "update cligotest golden files")
+
+// Result holds the outcome of a single application run.
+type Result struct {
+	Stdout string
+	Stderr string
+	// ExitCode is the code Main would exit with, see cligo.ExitCode
+	ExitCode int
+	Err      error
+}
+
+// Harness runs a cligo application configured with the given options.
+// Environment variables, config files and profiles are added with the builder methods.
+type Harness struct {
+	t        testing.TB
+	options  []cligo.Option
+	env      map[string]string
+	configs  []string
+	profiles []string
+}
+
+// New creates a harness for an application built from the given options.
+// The application name defaults to "app" so that help output does not depend on the test binary name.
+func New(t testing.TB, options ...cligo.Option) *Harness {
+	return &Harness{
+		t:       t,
+		options: append([]cligo.Option{cligo.Name("app")}, options...),
+		env:     make(map[string]string),
+	}
+}
+
+// Env sets an environment variable for the duration of the test.
+// Tests using Env cannot run in parallel, see testing.T.Setenv.
+func (h *Harness) Env(key, value string) *Harness {
+	h.env[key] = value
+	return h
+}
+
+// ConfigFile writes content to a temporary file with the given name and loads it as a config file.
+// The file extension selects the format, as with cligo.ConfigFile.
+func (h *Harness) ConfigFile(name, content string) *Harness {
+	h.t.Helper()
+	path := filepath.Join(h.t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		h.t.Fatalf("write config file %s: %v", name, err)
+	}
+	h.configs = append(h.configs, path)
+	return h
+}
+
+// Profile activates a glue profile.
+func (h *Harness) Profile(profile string) *Harness {
+	h.profiles = append(h.profiles, profile)
+	return h
+}
+
+// Run runs the application with the given arguments (without the program name)
+// and returns the captured output.
+func (h *Harness) Run(args ...string) *Result {
+	h.t.Helper()
+	for key, value := range h.env {
+		if setter, ok := h.t.(interface{ Setenv(key, value string) }); ok {
+			setter.Setenv(key, value)
+		} else {
+			h.t.Fatalf("environment variables require *testing.T or *testing.B, got %T", h.t)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	options := append([]cligo.Option{}, h.options...)
+	for _, path := range h.configs {
+		options = append(options, cligo.ConfigFile(path))
+	}
+	for _, profile := range h.profiles {
+		options = append(options, cligo.Profile(profile))
+	}
+	if args == nil {
+		args = []string{}
+	}
+	options = append(options, cligo.Args(args), cligo.Stdout(&stdout), cligo.Stderr(&stderr), cligo.Color(false))
+
+	err := cligo.Run(options...)
+	return &Result{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: cligo.ExitCode(err),
+		Err:      err,
+	}
+}
+
+// Help runs the application with --help appended to the given command path and returns the help output.
+// An empty path returns the root help.
+func (h *Harness) Help(path ...string) string {
+	h.t.Helper()
+	args := append(append([]string(nil), path...), "--help")
+	result := h.Run(args...)
+	if result.Err != nil {
+		h.t.Fatalf("help for %v: %v", path, result.Err)
+	}
+	return result.Stdout
+}
+
+// AssertGolden compares got with the golden file testdata/<name>.golden.
+// Run the tests with -cligotest.update to create or refresh golden files.
+func AssertGolden(t testing.TB, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("write golden file %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file %s: %v (run with -cligotest.update to create it)", path, err)
+	}
+	if string(want) != got {
+		t.Errorf("output does not match %s\n--- want ---\n%s\n--- got ---\n%s", path, want, got)
+	}
+}
+
+// AssertHelpGolden snapshots the help output of the given command path (root help when empty)
+// into testdata/<name>.golden.
+func (h *Harness) AssertHelpGolden(name string, path ...string) {
+	h.t.Helper()
+	AssertGolden(h.t, name, h.Help(path...))
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligotest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.arpabet.com/cligo"
+	"go.arpabet.com/cligo/cligotest"
+	"go.arpabet.com/glue"
+	"golang.org/x/xerrors"
+)
+
+// ─── fixtures ────────────────────────────────────────────────────────────────
+
+type greetCmd struct {
+	Parent cligo.CliGroup       `cli:"group=cli"`
+	Name   string               `cli:"argument=name"`
+	Loud   bool                 `cli:"option=loud,short=l,help=Shout the greeting"`
+	Port   int                  `cli:"option=port,default=8080,env=GREET_PORT,help=Port number"`
+	App    cligo.CliApplication `inject:""`
+	greet  string
+}
+
+func (c *greetCmd) Command() string        { return "greet" }
+func (c *greetCmd) Help() (string, string) { return "Greet someone.", "" }
+func (c *greetCmd) Run(_ context.Context) error {
+	c.greet = "Hello, " + c.Name
+	if c.Loud {
+		c.greet = strings.ToUpper(c.greet)
+	}
+	c.App.Echo("%s", c.greet)
+	return nil
+}
+
+type failCmd struct {
+	Parent cligo.CliGroup `cli:"group=cli"`
+}
+
+func (c *failCmd) Command() string             { return "fail" }
+func (c *failCmd) Help() (string, string)      { return "Always fails.", "" }
+func (c *failCmd) Run(_ context.Context) error { return xerrors.New("boom") }
+
+type propCmd struct {
+	Parent cligo.CliGroup `cli:"group=cli"`
+	Mode   string         `value:"app.mode"`
+}
+
+func (c *propCmd) Command() string             { return "prop" }
+func (c *propCmd) Help() (string, string)      { return "Read a property.", "" }
+func (c *propCmd) Run(_ context.Context) error { return nil }
+
+type devCmd struct {
+	Parent cligo.CliGroup `cli:"group=cli"`
+	ran    bool
+}
+
+func (c *devCmd) Command() string             { return "dev" }
+func (c *devCmd) Help() (string, string)      { return "Dev only.", "" }
+func (c *devCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// ─── Run ─────────────────────────────────────────────────────────────────────
+
+func TestRun_Success(t *testing.T) {
+	t.Parallel()
+	cmd := &greetCmd{}
+	res := cligotest.New(t, cligo.Beans(cmd)).Run("greet", "world", "-l")
+	if res.Err != nil || res.ExitCode != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if cmd.greet != "HELLO, WORLD" {
+		t.Errorf("expected HELLO, WORLD, got %q", cmd.greet)
+	}
+	if res.Stdout != "HELLO, WORLD\n" {
+		t.Errorf("expected greeting in stdout, got %q", res.Stdout)
+	}
+}
+
+func TestRun_Error_ExitCode(t *testing.T) {
+	t.Parallel()
+	res := cligotest.New(t, cligo.Beans(&failCmd{})).Run("fail")
+	if res.Err == nil || res.ExitCode == 0 {
+		t.Fatalf("expected failure, got %+v", res)
+	}
+	if !strings.Contains(res.Err.Error(), "boom") {
+		t.Errorf("expected boom error, got %v", res.Err)
+	}
+}
+
+func TestRun_UsageCapturedInStdout(t *testing.T) {
+	t.Parallel()
+	res := cligotest.New(t, cligo.Beans(&greetCmd{})).Run("greet")
+	if res.Err == nil || res.ExitCode != cligo.ExitCodeUsage {
+		t.Fatalf("expected missing argument usage error, got %+v", res)
+	}
+	if !strings.Contains(res.Stdout, "Try 'app greet --help' for help") {
+		t.Errorf("expected usage in stdout, got %q", res.Stdout)
+	}
+}
+
+func TestRun_Env(t *testing.T) {
+	cmd := &greetCmd{}
+	res := cligotest.New(t, cligo.Beans(cmd)).Env("GREET_PORT", "9090").Run("greet", "world")
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+	if cmd.Port != 9090 {
+		t.Errorf("expected Port=9090 from env, got %d", cmd.Port)
+	}
+}
+
+func TestRun_ConfigFile(t *testing.T) {
+	t.Parallel()
+	cmd := &propCmd{}
+	res := cligotest.New(t, cligo.Beans(cmd)).ConfigFile("app.properties", "app.mode=test").Run("prop")
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+	if cmd.Mode != "test" {
+		t.Errorf("expected Mode=test, got %q", cmd.Mode)
+	}
+}
+
+func TestRun_Profile(t *testing.T) {
+	t.Parallel()
+	cmd := &devCmd{}
+	res := cligotest.New(t, cligo.Beans(glue.IfProfile("dev", cmd))).Profile("dev").Run("dev")
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+	if !cmd.ran {
+		t.Error("expected dev command to run with dev profile active")
+	}
+}
+
+// ─── golden files ────────────────────────────────────────────────────────────
+
+func TestHelpGolden_Root(t *testing.T) {
+	t.Parallel()
+	cligotest.New(t, cligo.Help("Greeter."), cligo.Beans(&greetCmd{}, &failCmd{})).AssertHelpGolden("root_help")
+}
+
+func TestHelpGolden_Command(t *testing.T) {
+	t.Parallel()
+	cligotest.New(t, cligo.Beans(&greetCmd{})).AssertHelpGolden("greet_help", "greet")
+}
+
+func TestHelp_KeepsCallerSlice(t *testing.T) {
+	t.Parallel()
+	path := make([]string, 1, 2)
+	path[0] = "greet"
+	backing := path[:2]
+	backing[1] = "world"
+	if out := cligotest.New(t, cligo.Beans(&greetCmd{})).Help(path...); !strings.Contains(out, "Greet someone.") {
+		t.Errorf("expected greet help, got %q", out)
+	}
+	if backing[1] != "world" {
+		t.Errorf("Help wrote into the caller's slice: %v", backing)
+	}
+}
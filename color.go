@@ -5,7 +5,10 @@
 
 package cligo
 
-import "os"
+import (
+	"io"
+	"os"
+)
 
 const (
 	ansiReset  = "\033[0m"
@@ -23,7 +26,16 @@ func (t *implCliApplication) isColorEnabled() bool {
 	if os.Getenv("NO_COLOR") != "" {
 		return false
 	}
-	fi, err := os.Stdout.Stat()
+	return isTerminal(t.stdout)
+}
+
+// isTerminal reports whether w is a file attached to a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
 	if err != nil {
 		return false
 	}
@@ -9,6 +9,9 @@ import "golang.org/x/xerrors"
 
 // RegisterCommand registers a command
 func (t *implCliApplication) RegisterCommand(cmd CliCommand) error {
+	if err := validateExpandedCliTags(cmd, t.allProperties); err != nil {
+		return err
+	}
 	info := extractParentInfo(cmd)
 	if info.group == "" {
 		return xerrors.Errorf("parent group not found in cli command: %v", cmd)
@@ -29,6 +32,9 @@ func (t *implCliApplication) RegisterCommand(cmd CliCommand) error {
 
 // RegisterCommandWithBeans registers a command with beans
 func (t *implCliApplication) RegisterCommandWithBeans(cmd CliCommandWithBeans) error {
+	if err := validateExpandedCliTags(cmd, t.allProperties); err != nil {
+		return err
+	}
 	info := extractParentInfo(cmd)
 	if info.group == "" {
 		return xerrors.Errorf("parent group not found in cli command: %v", cmd)
@@ -0,0 +1,491 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"go.arpabet.com/glue"
+	"golang.org/x/xerrors"
+)
+
+// completionCommand is the name of the hidden built-in command that prints shell completion scripts.
+const completionCommand = "completion"
+
+// completeCommand is the name of the hidden entrypoint the completion scripts call to obtain
+// dynamic candidates from commands implementing CliCompleter.
+const completeCommand = "__complete"
+
+// completionEntry is a group or command that can follow a completion path.
+type completionEntry struct {
+	name  string
+	alias string
+	help  string
+}
+
+// completionFlag is an option that can be completed for a completion path.
+type completionFlag struct {
+	long  string
+	short string
+	help  string
+}
+
+// completionNode describes what can be completed after the canonical command path.
+// Dynamic nodes belong to commands implementing CliCompleter.
+type completionNode struct {
+	path     string
+	children []completionEntry
+	flags    []completionFlag
+	dynamic  bool
+}
+
+// printCompletion prints the completion script for the given shell.
+func (t *implCliApplication) printCompletion(args []string) error {
+	if len(args) == 0 {
+		t.Echo("%s: %s %s SHELL", t.styled("Usage", ansiBold), t.name, completionCommand)
+		return usageErrorf("missing required argument 'shell' (bash, zsh or fish)")
+	}
+
+	nodes := t.completionTree()
+	fn := completionFuncName(t.name)
+
+	switch args[0] {
+	case "bash":
+		fmt.Fprint(t.stdout, bashCompletion(t.name, fn, nodes))
+	case "zsh":
+		fmt.Fprint(t.stdout, zshCompletion(t.name, fn, nodes))
+	case "fish":
+		fmt.Fprint(t.stdout, fishCompletion(t.name, fn, nodes))
+	default:
+		return usageErrorf("unsupported shell: %s (expected bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+// completionTree walks the registered groups and commands, skipping hidden ones, and returns
+// a node for the root and for every visible group and command.
+func (t *implCliApplication) completionTree() []completionNode {
+	root := completionNode{
+		flags: t.globalCompletionFlags(),
+	}
+	var nodes []completionNode
+	nodes = t.walkCompletionGroup(RootGroup, "", root, nodes)
+	return nodes
+}
+
+func (t *implCliApplication) walkCompletionGroup(groupName, path string, node completionNode, nodes []completionNode) []completionNode {
+	var sub []completionNode
+
+	for _, grp := range t.groups[groupName] {
+		if t.hidden[grp] {
+			continue
+		}
+		shortDesc, _ := grp.Help()
+		node.children = append(node.children, completionEntry{name: grp.Group(), alias: t.aliasOf[grp], help: shortDesc})
+		child := completionNode{
+			path:  joinCompletionPath(path, grp.Group()),
+			flags: []completionFlag{{long: "help", short: "h", help: "Show this message and exit."}},
+		}
+		sub = t.walkCompletionGroup(grp.Group(), child.path, child, sub)
+	}
+
+	for _, cmd := range t.commands[groupName] {
+		if t.hidden[cmd] {
+			continue
+		}
+		shortDesc, _ := cmd.Help()
+		node.children = append(node.children, completionEntry{name: cmd.Command(), alias: t.aliasOf[cmd], help: shortDesc})
+		_, dynamic := cmd.(CliCompleter)
+		sub = append(sub, completionNode{
+			path:    joinCompletionPath(path, cmd.Command()),
+			flags:   t.commandCompletionFlags(cmd),
+			dynamic: dynamic || hasChoices(cmd),
+		})
+	}
+
+	nodes = append(nodes, node)
+	return append(nodes, sub...)
+}
+
+// globalCompletionFlags lists the root options that printHelp shows.
+func (t *implCliApplication) globalCompletionFlags() []completionFlag {
+	var flags []completionFlag
+	add := func(f globalFlag, help string) {
+		if f.long != "" {
+			flags = append(flags, completionFlag{long: f.long, short: f.short, help: help})
+		}
+	}
+	if t.version != "" {
+		add(t.globalFlags.version, "Show the version and exit.")
+	}
+	add(t.globalFlags.profile, "Activate glue profiles (comma-separated).")
+	add(t.globalFlags.config, "Load config file (repeatable).")
+	add(t.globalFlags.property, "Override a property (key=value, repeatable).")
+	for _, opt := range t.globalFlags.options {
+		add(opt.globalFlag, opt.helpText())
+	}
+	return append(flags,
+		completionFlag{long: "verbose", help: "Show extended logging information."},
+		completionFlag{long: "help", short: "h", help: "Show this message and exit."},
+	)
+}
+
+// hasChoices reports whether any argument or option of cmd has a choices= tag.
+func hasChoices(cmd CliCommand) bool {
+	for _, field := range cliFields(reflect.TypeOf(cmd)) {
+		if _, ok := field.tagParts["choices"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// commandCompletionFlags lists the options of a command as registered by identifyArgumentsAndOptions.
+func (t *implCliApplication) commandCompletionFlags(cmd CliCommand) []completionFlag {
+	flagSet, _, _ := t.commandCompletionFlagSet(cmd)
+
+	var flags []completionFlag
+	flagSet.VisitAll(func(f *pflag.Flag) {
+		flags = append(flags, completionFlag{long: f.Name, short: f.Shorthand, help: f.Usage})
+	})
+	return flags
+}
+
+// commandCompletionFlagSet registers the options of a command the same way executeCommand does.
+func (t *implCliApplication) commandCompletionFlagSet(cmd CliCommand) (*pflag.FlagSet, []argInfo, map[string]optInfo) {
+	cmdValue := reflect.ValueOf(cmd).Elem()
+	flagSet := pflag.NewFlagSet(cmd.Command(), pflag.ContinueOnError)
+	argDefs, options := t.identifyArgumentsAndOptions(cmdValue.Type(), cmdValue, flagSet)
+	if groupOptions, err := t.addGroupOptions(flagSet, t.groupChain(extractParentInfo(cmd).group)); err == nil {
+		for name, opt := range groupOptions {
+			options[name] = opt
+		}
+	}
+	flagSet.BoolP("help", "h", false, "Print help")
+	flagSet.Bool("verbose", false, "Verbose output")
+	return flagSet, argDefs, options
+}
+
+// complete prints dynamic completion candidates, one per line, for the words typed so far.
+// The last word is the partial word being completed. Groups and commands are resolved
+// through findGroup/findCommand; once a command is found, the argument or option being
+// typed is determined and its choices are printed; if the command implements CliCompleter,
+// it is also asked for candidates.
+func (t *implCliApplication) complete(ctx context.Context, c glue.Container, words []string) error {
+	words = joinCompletionAssignments(words)
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	partial := words[len(words)-1]
+	typed := words[:len(words)-1]
+
+	currentGroup := RootGroup
+	for i := 0; i < len(typed); i++ {
+		word := typed[i]
+		if strings.HasPrefix(word, "-") {
+			if t.globalFlags.isValueFlag(word) {
+				i++
+			}
+			continue
+		}
+		if grp := t.findGroup(currentGroup, word); grp != nil {
+			currentGroup = grp.Group()
+			continue
+		}
+		if cmd := t.findCommand(currentGroup, word); cmd != nil {
+			return t.completeCommand(ctx, c, cmd, typed[i+1:], partial)
+		}
+	}
+
+	if strings.HasPrefix(partial, "-") {
+		return nil
+	}
+	for _, grp := range t.groups[currentGroup] {
+		if !t.hidden[grp] && strings.HasPrefix(grp.Group(), partial) {
+			t.Echo("%s", grp.Group())
+		}
+	}
+	for _, cmd := range t.commands[currentGroup] {
+		if !t.hidden[cmd] && strings.HasPrefix(cmd.Command(), partial) {
+			t.Echo("%s", cmd.Command())
+		}
+	}
+	return nil
+}
+
+// completeCommand resolves which argument or option of cmd is being typed and prints its candidates.
+func (t *implCliApplication) completeCommand(ctx context.Context, c glue.Container, cmd CliCommand, typed []string, partial string) error {
+	completer, _ := cmd.(CliCompleter)
+	flagSet, argDefs, options := t.commandCompletionFlagSet(cmd)
+
+	// --name=partial completes the value of the option
+	if strings.HasPrefix(partial, "--") {
+		name, value, hasValue := strings.Cut(partial[2:], "=")
+		if !hasValue || flagSet.Lookup(name) == nil {
+			return nil
+		}
+		return t.echoCandidates(ctx, c, cmd, completer, name, value, "--"+name+"=", options[name].choices)
+	}
+	if strings.HasPrefix(partial, "-") {
+		return nil
+	}
+
+	// the previous word is an option expecting a separate value
+	position := 0
+	for i := 0; i < len(typed); i++ {
+		word := typed[i]
+		if word == "--" {
+			position += len(typed) - i - 1
+			break
+		}
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			f := completionLookupFlag(flagSet, word)
+			if f != nil && f.NoOptDefVal == "" && !strings.Contains(word, "=") {
+				if i == len(typed)-1 {
+					return t.echoCandidates(ctx, c, cmd, completer, f.Name, partial, "", options[f.Name].choices)
+				}
+				i++
+			}
+			continue
+		}
+		position++
+	}
+
+	for i, arg := range argDefs {
+		// a variadic argument takes every position from its own on
+		if i == position || arg.variadic && i < position {
+			return t.echoCandidates(ctx, c, cmd, completer, arg.name, partial, "", arg.choices)
+		}
+	}
+	return nil
+}
+
+// echoCandidates prints the choices matching partial, then the candidates returned by the completer,
+// if any, within the command scope when it has beans.
+func (t *implCliApplication) echoCandidates(ctx context.Context, c glue.Container, cmd CliCommand, completer CliCompleter, name, partial, prefix string, choices []string) error {
+	for _, choice := range choices {
+		if strings.HasPrefix(choice, partial) {
+			t.Echo("%s%s", prefix, choice)
+		}
+	}
+	if completer == nil {
+		return nil
+	}
+	cmdBeans, ok := t.commandBeans[cmd.Command()]
+	if ok && len(cmdBeans) > 0 {
+		child, err := c.Extend(cmdBeans...)
+		if err != nil {
+			return xerrors.Errorf("fail to initialize '%s' command scope context, %v", cmd.Command(), err)
+		}
+		defer child.Close()
+	}
+	for _, candidate := range completer.Complete(ctx, name, partial) {
+		t.Echo("%s%s", prefix, candidate)
+	}
+	return nil
+}
+
+// completionLookupFlag finds the flag for a typed --name, --name=value, -s or -s=value word.
+func completionLookupFlag(flagSet *pflag.FlagSet, word string) *pflag.Flag {
+	if strings.HasPrefix(word, "--") {
+		name, _, _ := strings.Cut(word[2:], "=")
+		return flagSet.Lookup(name)
+	}
+	shorts, _, _ := strings.Cut(word[1:], "=")
+	if shorts == "" {
+		return nil
+	}
+	// in a combined -abc form only the last shorthand can take a separate value
+	return flagSet.ShorthandLookup(shorts[len(shorts)-1:])
+}
+
+// joinCompletionAssignments undoes the word splitting bash performs on '=' (see COMP_WORDBREAKS):
+// "--env", "=", "pr" becomes "--env", "pr", and a trailing "--env", "=" becomes "--env", "".
+func joinCompletionAssignments(words []string) []string {
+	var out []string
+	for i, word := range words {
+		if word == "=" && len(out) > 0 && strings.HasPrefix(out[len(out)-1], "-") && !strings.Contains(out[len(out)-1], "=") {
+			if i == len(words)-1 {
+				out = append(out, "")
+			}
+			continue
+		}
+		out = append(out, word)
+	}
+	return out
+}
+
+func joinCompletionPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + " " + name
+}
+
+// completionFuncName derives a shell function name from the application name.
+func completionFuncName(name string) string {
+	var str strings.Builder
+	str.WriteString("__")
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			str.WriteRune(r)
+		} else {
+			str.WriteRune('_')
+		}
+	}
+	str.WriteString("_cligo")
+	return str.String()
+}
+
+// completionWords returns the candidate words of a node in the form typed on the command line.
+func completionWords(node completionNode) []string {
+	var words []string
+	for _, child := range node.children {
+		words = append(words, child.name)
+	}
+	for _, f := range node.flags {
+		words = append(words, "--"+f.long)
+		if f.short != "" {
+			words = append(words, "-"+f.short)
+		}
+	}
+	return words
+}
+
+// writePathTransitions writes one shell case branch per group or command name (and alias), mapping
+// the typed path to the canonical one. Patterns are rendered by the pattern function, and each
+// branch body is the assign statement followed by the shell-specific terminator.
+func writePathTransitions(str *strings.Builder, nodes []completionNode, indent string, pattern func(typed []string) string, assign, terminator string) {
+	for _, node := range nodes {
+		for _, child := range node.children {
+			typed := []string{joinCompletionPath(node.path, child.name)}
+			if child.alias != "" {
+				typed = append(typed, joinCompletionPath(node.path, child.alias))
+			}
+			fmt.Fprintf(str, "%s%s\n", indent, pattern(typed))
+			fmt.Fprintf(str, "%s    %s%s%s\n", indent, assign, shellQuote(joinCompletionPath(node.path, child.name)), terminator)
+		}
+	}
+}
+
+func bashCompletion(name, fn string, nodes []completionNode) string {
+	var str strings.Builder
+	fmt.Fprintf(&str, "# bash completion for %s\n", name)
+	fmt.Fprintf(&str, "%s() {\n", fn)
+	str.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" cmdpath=\"\" word candidates i\n")
+	str.WriteString("    [[ \"$cur\" == \"=\" ]] && cur=\"\"\n")
+	str.WriteString("    for ((i=1; i<COMP_CWORD; i++)); do\n")
+	str.WriteString("        word=\"${COMP_WORDS[i]}\"\n")
+	str.WriteString("        [[ \"$word\" == -* ]] && continue\n")
+	str.WriteString("        case \"${cmdpath:+$cmdpath }$word\" in\n")
+	writePathTransitions(&str, nodes, "            ", func(typed []string) string {
+		return quoteAll(typed, "|") + ")"
+	}, "cmdpath=", " ;;")
+	str.WriteString("        esac\n")
+	str.WriteString("    done\n")
+	str.WriteString("    case \"$cmdpath\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&str, "        %s) candidates=%s", shellQuote(node.path), shellQuote(strings.Join(completionWords(node), " ")))
+		if node.dynamic {
+			fmt.Fprintf(&str, "\n            candidates=\"$candidates $(\"${COMP_WORDS[0]}\" %s \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null)\"", completeCommand)
+		}
+		str.WriteString(" ;;\n")
+	}
+	str.WriteString("    esac\n")
+	str.WriteString("    COMPREPLY=($(compgen -W \"$candidates\" -- \"$cur\"))\n")
+	str.WriteString("}\n")
+	fmt.Fprintf(&str, "complete -F %s %s\n", fn, name)
+	return str.String()
+}
+
+func zshCompletion(name, fn string, nodes []completionNode) string {
+	var str strings.Builder
+	fmt.Fprintf(&str, "#compdef %s\n", name)
+	fmt.Fprintf(&str, "# zsh completion for %s\n", name)
+	fmt.Fprintf(&str, "%s() {\n", fn)
+	str.WriteString("    local cmdpath=\"\" word i\n")
+	str.WriteString("    local -a candidates\n")
+	str.WriteString("    for ((i=2; i<CURRENT; i++)); do\n")
+	str.WriteString("        word=\"${words[i]}\"\n")
+	str.WriteString("        [[ \"$word\" == -* ]] && continue\n")
+	str.WriteString("        case \"${cmdpath:+$cmdpath }$word\" in\n")
+	writePathTransitions(&str, nodes, "            ", func(typed []string) string {
+		return "(" + quoteAll(typed, "|") + ")"
+	}, "cmdpath=", " ;;")
+	str.WriteString("        esac\n")
+	str.WriteString("    done\n")
+	str.WriteString("    case \"$cmdpath\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&str, "        (%s) candidates=(%s)", shellQuote(node.path), quoteAll(completionWords(node), " "))
+		if node.dynamic {
+			fmt.Fprintf(&str, "\n            candidates+=(${(f)\"$(${words[1]} %s \"${(@)words[2,CURRENT]}\" 2>/dev/null)\"})", completeCommand)
+		}
+		str.WriteString(" ;;\n")
+	}
+	str.WriteString("    esac\n")
+	str.WriteString("    compadd -- $candidates\n")
+	str.WriteString("}\n")
+	fmt.Fprintf(&str, "compdef %s %s\n", fn, name)
+	return str.String()
+}
+
+func fishCompletion(name, fn string, nodes []completionNode) string {
+	var str strings.Builder
+	fmt.Fprintf(&str, "# fish completion for %s\n", name)
+	fmt.Fprintf(&str, "function %s_path\n", fn)
+	str.WriteString("    set -l cmdpath ''\n")
+	str.WriteString("    for word in (commandline -opc)[2..-1]\n")
+	str.WriteString("        if string match -q -- '-*' $word\n")
+	str.WriteString("            continue\n")
+	str.WriteString("        end\n")
+	str.WriteString("        switch (string trim -- \"$cmdpath $word\")\n")
+	writePathTransitions(&str, nodes, "            ", func(typed []string) string {
+		return "case " + quoteAll(typed, " ")
+	}, "set cmdpath ", "")
+	str.WriteString("        end\n")
+	str.WriteString("    end\n")
+	str.WriteString("    echo $cmdpath\n")
+	str.WriteString("end\n\n")
+	fmt.Fprintf(&str, "function %s_using\n", fn)
+	fmt.Fprintf(&str, "    set -l cmdpath (%s_path)\n", fn)
+	str.WriteString("    test \"$cmdpath\" = \"$argv[1]\"\n")
+	str.WriteString("end\n\n")
+	fmt.Fprintf(&str, "complete -c %s -f\n", name)
+	for _, node := range nodes {
+		cond := shellQuote(fn + "_using \"" + node.path + "\"")
+		for _, child := range node.children {
+			fmt.Fprintf(&str, "complete -c %s -n %s -a %s -d %s\n", name, cond, shellQuote(child.name), shellQuote(child.help))
+		}
+		for _, f := range node.flags {
+			short := ""
+			if f.short != "" {
+				short = " -s " + f.short
+			}
+			fmt.Fprintf(&str, "complete -c %s -n %s -l %s%s -d %s\n", name, cond, f.long, short, shellQuote(f.help))
+		}
+		if node.dynamic {
+			fmt.Fprintf(&str, "complete -c %s -n %s -a %s\n", name, cond, shellQuote("("+name+" "+completeCommand+" (commandline -opc)[2..-1] (commandline -ct))"))
+		}
+	}
+	return str.String()
+}
+
+// shellQuote wraps s in single quotes, escaping embedded single quotes for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quoteAll(words []string, sep string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = shellQuote(w)
+	}
+	return strings.Join(quoted, sep)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── completion command ──────────────────────────────────────────────────────
+
+func TestCompletion_Bash(t *testing.T) {
+	withArgs([]string{"app", "completion", "bash"}, func() {
+		out := captureOutput(func() {
+			if err := Run(Name("app"), Beans(&shipGroup{}, &moveShipCmd{})); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(out, "complete -F __app_cligo app") {
+			t.Errorf("expected complete -F registration, got:\n%s", out)
+		}
+		if !strings.Contains(out, "'ship move') candidates=") {
+			t.Errorf("expected candidates for 'ship move', got:\n%s", out)
+		}
+		if !strings.Contains(out, "--speed -s") {
+			t.Errorf("expected --speed and -s in candidates, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_Zsh(t *testing.T) {
+	withArgs([]string{"app", "completion", "zsh"}, func() {
+		out := captureOutput(func() {
+			if err := Run(Name("app"), Beans(&shipGroup{}, &moveShipCmd{})); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+		if !strings.HasPrefix(out, "#compdef app") {
+			t.Errorf("expected #compdef header, got:\n%s", out)
+		}
+		if !strings.Contains(out, "compdef __app_cligo app") {
+			t.Errorf("expected compdef registration, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_Fish(t *testing.T) {
+	withArgs([]string{"app", "completion", "fish"}, func() {
+		out := captureOutput(func() {
+			if err := Run(Name("app"), Beans(&shipGroup{}, &moveShipCmd{})); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(out, `complete -c app -n '__app_cligo_using "ship move"' -l speed -s s -d 'Speed in knots'`) {
+			t.Errorf("expected fish completion for --speed, got:\n%s", out)
+		}
+		if !strings.Contains(out, `-a 'ship' -d 'Manage ships.'`) {
+			t.Errorf("expected fish completion for ship group, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_ExcludesHidden(t *testing.T) {
+	withArgs([]string{"app", "completion", "bash"}, func() {
+		out := captureOutput(func() {
+			_ = Run(Beans(&hiddenCmd{}, &hiddenGroupDef{}, &envCmd{}))
+		})
+		if strings.Contains(out, "secret") || strings.Contains(out, "internal") {
+			t.Errorf("hidden commands should not be completed, got:\n%s", out)
+		}
+		if !strings.Contains(out, "envcmd") {
+			t.Errorf("expected envcmd in completion, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_Aliases(t *testing.T) {
+	withArgs([]string{"app", "completion", "bash"}, func() {
+		out := captureOutput(func() {
+			_ = Run(Beans(&aliasedGroup{}, &aliasedCmd{}))
+		})
+		if !strings.Contains(out, "'ship'|'s')") {
+			t.Errorf("expected group alias transition, got:\n%s", out)
+		}
+		if !strings.Contains(out, "'ship new'|'ship n')") {
+			t.Errorf("expected command alias transition, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_NotInHelp(t *testing.T) {
+	withArgs([]string{"app", "--help"}, func() {
+		out := captureOutput(func() {
+			_ = Run(Beans(&shipGroup{}))
+		})
+		if strings.Contains(out, "completion") {
+			t.Errorf("completion command should be hidden, got:\n%s", out)
+		}
+	})
+}
+
+func TestCompletion_UnsupportedShell_ReturnsError(t *testing.T) {
+	withArgs([]string{"app", "completion", "tcsh"}, func() {
+		captureOutput(func() {
+			err := Run()
+			if err == nil || !strings.Contains(err.Error(), "unsupported shell") {
+				t.Errorf("expected unsupported shell error, got: %v", err)
+			}
+		})
+	})
+}
+
+func TestCompletion_MissingShell_ReturnsError(t *testing.T) {
+	withArgs([]string{"app", "completion"}, func() {
+		captureOutput(func() {
+			if err := Run(); err == nil {
+				t.Error("expected error for missing shell argument")
+			}
+		})
+	})
+}
+
+// ─── dynamic completion ──────────────────────────────────────────────────────
+
+func completeOutput(t *testing.T, words ...string) []string {
+	t.Helper()
+	var out string
+	withArgs(append([]string{"app", "__complete"}, words...), func() {
+		out = captureOutput(func() {
+			if err := Run(Beans(&shipGroup{}, &moveShipCmd{}, &deployCmd{})); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	})
+	return strings.Fields(out)
+}
+
+func TestComplete_Argument(t *testing.T) {
+	got := completeOutput(t, "deploy", "w")
+	if strings.Join(got, " ") != "web worker" {
+		t.Errorf("expected [web worker], got %v", got)
+	}
+}
+
+func TestComplete_Argument_EmptyPartial(t *testing.T) {
+	// zsh and bash pass the empty word under the cursor as the last word
+	got := completeOutput(t, "deploy", "")
+	if strings.Join(got, " ") != "api web worker" {
+		t.Errorf("expected [api web worker], got %v", got)
+	}
+}
+
+func TestCompletion_Zsh_DynamicCommand(t *testing.T) {
+	withArgs([]string{"app", "completion", "zsh"}, func() {
+		out := captureOutput(func() {
+			_ = Run(Name("app"), Beans(&deployCmd{}))
+		})
+		// Quoted so zsh keeps the empty word under the cursor
+		if !strings.Contains(out, `__complete "${(@)words[2,CURRENT]}" 2>/dev/null`) {
+			t.Errorf("expected quoted words in the __complete call, got:\n%s", out)
+		}
+	})
+}
+
+func TestComplete_OptionValue_Separate(t *testing.T) {
+	got := completeOutput(t, "deploy", "--env", "")
+	if strings.Join(got, " ") != "prod staging" {
+		t.Errorf("expected [prod staging], got %v", got)
+	}
+	got = completeOutput(t, "deploy", "-e", "st")
+	if strings.Join(got, " ") != "staging" {
+		t.Errorf("expected [staging], got %v", got)
+	}
+}
+
+func TestComplete_OptionValue_Assigned(t *testing.T) {
+	got := completeOutput(t, "deploy", "--env=pr")
+	if strings.Join(got, " ") != "--env=prod" {
+		t.Errorf("expected [--env=prod], got %v", got)
+	}
+	// bash splits words on '='
+	got = completeOutput(t, "deploy", "--env", "=", "pr")
+	if strings.Join(got, " ") != "prod" {
+		t.Errorf("expected [prod], got %v", got)
+	}
+}
+
+func TestComplete_ArgumentAfterBoolAndValueOptions(t *testing.T) {
+	got := completeOutput(t, "deploy", "--force", "-e", "prod", "a")
+	if strings.Join(got, " ") != "api" {
+		t.Errorf("expected [api], got %v", got)
+	}
+}
+
+func TestComplete_NoCompleter_NoCandidates(t *testing.T) {
+	got := completeOutput(t, "ship", "move", "")
+	if len(got) != 0 {
+		t.Errorf("expected no candidates, got %v", got)
+	}
+}
+
+func TestComplete_GroupChildren(t *testing.T) {
+	got := completeOutput(t, "-p", "dev", "ship", "")
+	if strings.Join(got, " ") != "move" {
+		t.Errorf("expected [move], got %v", got)
+	}
+	got = completeOutput(t, "d")
+	if strings.Join(got, " ") != "deploy" {
+		t.Errorf("expected [deploy], got %v", got)
+	}
+}
+
+func TestCompletion_Bash_DynamicCommand(t *testing.T) {
+	withArgs([]string{"app", "completion", "bash"}, func() {
+		out := captureOutput(func() {
+			_ = Run(Name("app"), Beans(&deployCmd{}, &shipGroup{}, &moveShipCmd{}))
+		})
+		if strings.Count(out, "__complete") != 1 {
+			t.Errorf("expected exactly one __complete call for the dynamic command, got:\n%s", out)
+		}
+	})
+}
@@ -9,28 +9,155 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	"go.arpabet.com/glue"
 	"golang.org/x/xerrors"
 )
 
-// resolveConfigFiles finds the first existing file from the given paths
-// and returns glue beans for property loading.
-// For .properties/.yaml/.yml/.json files, returns a glue.PropertySource bean.
-// For .env files, returns a parsed glue.MapPropertySource bean.
-func resolveConfigFiles(paths []string) ([]interface{}, error) {
-	for _, path := range paths {
+// resolveConfigFiles returns glue beans loading the config files into glue.Properties.
+// By default only the first existing file is loaded, trying the --config paths in overrides
+// before the ConfigFile paths. With merge, see MergeConfigFiles, every existing file is loaded
+// in declared order, the ConfigFile paths first and the --config paths on top, each file
+// overriding the ones loaded before it.
+// For .properties/.yaml/.yml/.json/.toml files, returns a glue.PropertySource bean per file.
+func resolveConfigFiles(paths, overrides []string, merge bool) ([]interface{}, error) {
+	if !merge {
+		for _, path := range append(append([]string(nil), overrides...), paths...) {
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			bean, err := configFileBean(path)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{bean}, nil
+		}
+		return nil, nil // no file found, not an error
+	}
+
+	var beans []interface{}
+	for _, path := range append(append([]string(nil), paths...), overrides...) {
 		if _, err := os.Stat(path); err != nil {
 			continue
 		}
+		bean, err := configFileBean(path)
+		if err != nil {
+			return nil, err
+		}
+		beans = append(beans, bean)
+	}
+	return beans, nil
+}
+
+// configFileBean returns the glue bean loading an existing config file, by its extension.
+func configFileBean(path string) (interface{}, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".properties", ".yaml", ".yml", ".json", ".toml":
+		return &glue.PropertySource{File: "file:" + path}, nil
+	default:
+		return nil, xerrors.Errorf("unsupported config file format: %s", ext)
+	}
+}
+
+// configExtensions are the config file extensions DiscoverConfig looks for, in order of preference.
+var configExtensions = []string{".yaml", ".toml", ".json", ".properties"}
 
-		ext := strings.ToLower(filepath.Ext(path))
-		switch ext {
-		case ".properties", ".yaml", ".yml", ".json", ".toml":
-			return []interface{}{&glue.PropertySource{File: "file:" + path}}, nil
-		default:
-			return nil, xerrors.Errorf("unsupported config file format: %s", ext)
+// systemConfigDir is the directory holding system-wide config, <name>/config.* inside it.
+var systemConfigDir = "/etc"
+
+// configEnvVar returns the environment variable naming the config file of the application,
+// e.g. MY_APP_CONFIG for my-app.
+func configEnvVar(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name) + "_CONFIG"
+}
+
+// discoverConfigFiles returns the existing config files of the application named name, lowest
+// priority first: /etc/<name>/config.*, $XDG_CONFIG_HOME/<name>/config.* (~/.config/<name>
+// when unset), then <name>.* in every directory from the repository root down to the current
+// directory, or in the current directory alone outside a repository. At most one file is taken
+// per directory, by the order of configExtensions. A file named by <NAME>_CONFIG replaces the search,
+// and must exist.
+func discoverConfigFiles(name string) ([]string, error) {
+	envVar := configEnvVar(name)
+	if path := os.Getenv(envVar); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return nil, xerrors.Errorf("config file from $%s: %w", envVar, err)
 		}
+		return []string{path}, nil
+	}
+
+	var files []string
+	add := func(dir, base string) {
+		for _, ext := range configExtensions {
+			path := filepath.Join(dir, base+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				files = append(files, path)
+				return
+			}
+		}
+	}
+
+	add(filepath.Join(systemConfigDir, name), "config")
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		add(filepath.Join(configHome, name), "config")
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		for _, dir := range projectDirs(cwd) {
+			add(dir, name)
+		}
+	}
+	return files, nil
+}
+
+// projectDirs returns the directories from the repository root containing cwd, marked by a .git
+// entry, down to cwd. Outside a repository it returns cwd alone.
+func projectDirs(cwd string) []string {
+	dirs := []string{cwd}
+	for dir := cwd; ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dirs
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return []string{cwd}
+		}
+		dir = parent
+		dirs = append([]string{dir}, dirs...)
+	}
+}
+
+// configPaths returns the ConfigFile paths together with the files found by DiscoverConfig, in
+// the order resolveConfigFiles expects: the discovered files come first when merging, so the
+// ConfigFile paths override them, and are tried last, most specific first, otherwise.
+func (t *implCliApplication) configPaths() ([]string, error) {
+	if !t.discovery {
+		return t.configFiles, nil
+	}
+	discovered, err := discoverConfigFiles(t.name)
+	if err != nil {
+		return nil, err
+	}
+	if t.mergeConfig {
+		return append(discovered, t.configFiles...), nil
+	}
+	paths := append([]string(nil), t.configFiles...)
+	for i := len(discovered) - 1; i >= 0; i-- {
+		paths = append(paths, discovered[i])
 	}
-	return nil, nil // no file found, not an error
+	return paths, nil
 }
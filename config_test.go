@@ -6,6 +6,8 @@
 package cligo
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -229,3 +231,171 @@ func TestConfigFlag_ShortFlag(t *testing.T) {
 		t.Errorf("expected Profile=short, got %q", cmd.Profile)
 	}
 }
+
+// ─── layered config files ────────────────────────────────────────────────────
+
+func TestConfigFlag_WinsOverConfigFileOption(t *testing.T) {
+	option := writeTempFile(t, "option.properties", "app.profile=option\napp.port=1")
+	flag := writeTempFile(t, "flag.properties", "app.profile=flag")
+	cmd := &propCmd{}
+	if err := Run(Args([]string{"--config", flag, "propcmd"}), ConfigFile(option), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "flag" || cmd.Port != "" {
+		t.Errorf("expected only the --config file loaded, got Profile=%q Port=%q", cmd.Profile, cmd.Port)
+	}
+}
+
+func TestMergeConfigFiles_LaterFilesOverride(t *testing.T) {
+	system := writeTempFile(t, "system.properties", "app.profile=system\napp.port=1")
+	user := writeTempFile(t, "user.yaml", "app:\n  profile: user\n")
+	cmd := &propCmd{}
+	err := Run(Args([]string{"propcmd"}), MergeConfigFiles(), Beans(cmd),
+		ConfigFile(system), ConfigFile("/nonexistent/app.yaml"), ConfigFile(user))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "user" || cmd.Port != "1" {
+		t.Errorf("expected user file over system file, got Profile=%q Port=%q", cmd.Profile, cmd.Port)
+	}
+}
+
+func TestMergeConfigFiles_ConfigFlagOnTop(t *testing.T) {
+	system := writeTempFile(t, "system.properties", "app.profile=system\napp.port=1")
+	project := writeTempFile(t, "project.properties", "app.profile=project")
+	flag := writeTempFile(t, "flag.properties", "app.port=2")
+	cmd := &propCmd{}
+	err := Run(Args([]string{"-c", flag, "propcmd"}), MergeConfigFiles(), Beans(cmd), ConfigFile(system), ConfigFile(project))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "project" || cmd.Port != "2" {
+		t.Errorf("expected --config on top of merged files, got Profile=%q Port=%q", cmd.Profile, cmd.Port)
+	}
+}
+
+func TestMergeConfigFiles_UnsupportedExtension(t *testing.T) {
+	good := writeTempFile(t, "good.properties", "app.port=1")
+	bad := writeTempFile(t, "config.xml", `<config/>`)
+	err := Run(Args([]string{"propcmd"}), MergeConfigFiles(), Beans(&propCmd{}), ConfigFile(good), ConfigFile(bad))
+	if err == nil || !strings.Contains(err.Error(), "unsupported config file format: .xml") {
+		t.Errorf("expected unsupported format error, got: %v", err)
+	}
+}
+
+// ─── config discovery ────────────────────────────────────────────────────────
+
+// discoveryDirs points the system and XDG config directories into a temporary tree and changes
+// into a project directory nested in a repository: root/.git and root/sub.
+func discoveryDirs(t *testing.T) (etc, xdg, root, sub string) {
+	t.Helper()
+	base := t.TempDir()
+	etc, xdg, root = filepath.Join(base, "etc"), filepath.Join(base, "xdg"), filepath.Join(base, "repo")
+	sub = filepath.Join(root, "sub")
+	for _, dir := range []string{filepath.Join(etc, "tool"), filepath.Join(xdg, "tool"), filepath.Join(root, ".git"), sub} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldEtc := systemConfigDir
+	systemConfigDir = etc
+	t.Cleanup(func() { systemConfigDir = oldEtc })
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("TOOL_CONFIG", "")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(sub); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return etc, xdg, root, sub
+}
+
+func TestDiscoverConfigFiles(t *testing.T) {
+	etc, xdg, root, sub := discoveryDirs(t)
+	writeFile(t, filepath.Join(etc, "tool", "config.properties"), "")
+	writeFile(t, filepath.Join(xdg, "tool", "config.yaml"), "")
+	writeFile(t, filepath.Join(xdg, "tool", "config.json"), "")
+	writeFile(t, filepath.Join(root, "tool.toml"), "")
+	writeFile(t, filepath.Join(sub, "tool.json"), "")
+
+	got, err := discoverConfigFiles("tool")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		filepath.Join(etc, "tool", "config.properties"),
+		filepath.Join(xdg, "tool", "config.yaml"),
+		filepath.Join(root, "tool.toml"),
+		filepath.Join(sub, "tool.json"),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("discoverConfigFiles() = %v, want %v", got, want)
+	}
+
+	custom := filepath.Join(sub, "custom.yaml")
+	writeFile(t, custom, "")
+	t.Setenv("TOOL_CONFIG", custom)
+	if got, err := discoverConfigFiles("tool"); err != nil || len(got) != 1 || got[0] != custom {
+		t.Errorf("expected $TOOL_CONFIG to replace the search, got %v, %v", got, err)
+	}
+}
+
+func TestDiscoverConfig_EnvOverrideMissing(t *testing.T) {
+	discoveryDirs(t)
+	t.Setenv("TOOL_CONFIG", "/nonexistent/tool.yaml")
+	err := Run(Name("tool"), Args([]string{"propcmd"}), DiscoverConfig(), Beans(&propCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "config file from $TOOL_CONFIG") {
+		t.Errorf("expected missing config file error, got: %v", err)
+	}
+}
+
+func TestConfigEnvVar(t *testing.T) {
+	if got := configEnvVar("my-app.v2"); got != "MY_APP_V2_CONFIG" {
+		t.Errorf("configEnvVar() = %q", got)
+	}
+}
+
+func TestDiscoverConfig_MostSpecificWins(t *testing.T) {
+	etc, _, _, sub := discoveryDirs(t)
+	writeFile(t, filepath.Join(etc, "tool", "config.properties"), "app.profile=system\napp.port=1")
+	writeFile(t, filepath.Join(sub, "tool.properties"), "app.profile=project")
+	cmd := &propCmd{}
+	if err := Run(Name("tool"), Args([]string{"propcmd"}), DiscoverConfig(), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "project" || cmd.Port != "" {
+		t.Errorf("expected only the project file, got Profile=%q Port=%q", cmd.Profile, cmd.Port)
+	}
+}
+
+func TestDiscoverConfig_Merge(t *testing.T) {
+	etc, xdg, root, _ := discoveryDirs(t)
+	writeFile(t, filepath.Join(etc, "tool", "config.properties"), "app.profile=system\napp.port=1")
+	writeFile(t, filepath.Join(xdg, "tool", "config.properties"), "app.profile=user")
+	writeFile(t, filepath.Join(root, "tool.properties"), "app.port=2")
+	cmd := &propCmd{}
+	if err := Run(Name("tool"), Args([]string{"propcmd"}), DiscoverConfig(), MergeConfigFiles(), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "user" || cmd.Port != "2" {
+		t.Errorf("expected merged discovered files, got Profile=%q Port=%q", cmd.Profile, cmd.Port)
+	}
+}
+
+func TestDiscoverConfig_EnvOverride(t *testing.T) {
+	etc, _, _, _ := discoveryDirs(t)
+	writeFile(t, filepath.Join(etc, "tool", "config.properties"), "app.profile=system")
+	custom := writeTempFile(t, "custom.properties", "app.profile=custom")
+	t.Setenv("TOOL_CONFIG", custom)
+	cmd := &propCmd{}
+	if err := Run(Name("tool"), Args([]string{"propcmd"}), DiscoverConfig(), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Profile != "custom" {
+		t.Errorf("expected the file from $TOOL_CONFIG, got Profile=%q", cmd.Profile)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/xerrors"
+)
+
+// constraints are the min=, max=, pattern= and exists= rules a bound value must satisfy.
+// For slices they apply to every element.
+type constraints struct {
+	min     reflect.Value
+	max     reflect.Value
+	pattern *regexp.Regexp
+	exists  string
+}
+
+// isNumericKind reports whether min= and max= bound the values of type typ.
+func isNumericKind(typ reflect.Type) bool {
+	if isCustomType(typ) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseConstraints parses the constraint tags of a field whose values have type typ, the element
+// type for slices. The number of values a variadic argument accepts is set by minargs= and
+// maxargs= instead, see parseArity.
+func parseConstraints(typ reflect.Type, tagParts map[string]string) (constraints, error) {
+	var c constraints
+	for _, key := range []string{"min", "max"} {
+		val, ok := tagParts[key]
+		if !ok {
+			continue
+		}
+		if !isNumericKind(typ) {
+			return c, xerrors.New("min= and max= apply only to numeric fields")
+		}
+		bound := reflect.New(typ).Elem()
+		if err := setFieldFromString(bound, val, ""); err != nil {
+			return c, xerrors.Errorf("invalid %s %q for %s", key, val, typ)
+		}
+		if key == "min" {
+			c.min = bound
+		} else {
+			c.max = bound
+		}
+	}
+	if c.min.IsValid() && c.max.IsValid() && compareNumbers(c.max, c.min) < 0 {
+		return c, xerrors.Errorf("max %s is less than min %s", tagParts["max"], tagParts["min"])
+	}
+
+	isString := typ.Kind() == reflect.String && !isCustomType(typ)
+	if val, ok := tagParts["pattern"]; ok {
+		if !isString {
+			return c, xerrors.New("pattern= applies only to string fields")
+		}
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return c, xerrors.Errorf("invalid pattern %q: %v", val, err)
+		}
+		c.pattern = re
+	}
+	if val, ok := tagParts["exists"]; ok {
+		if !isString {
+			return c, xerrors.New("exists= applies only to string fields")
+		}
+		if val != "file" && val != "dir" {
+			return c, xerrors.Errorf("invalid exists %q, expected file or dir", val)
+		}
+		c.exists = val
+	}
+	return c, nil
+}
+
+// compareNumbers returns -1, 0 or 1 as a is less than, equal to or greater than b, both of the same numeric type.
+func compareNumbers(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, y := a.Int(), b.Int()
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x, y := a.Uint(), b.Uint()
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// rangeViolation describes how value breaks the min=, max= and pattern= constraints, or returns ""
+// when it satisfies them. It needs no file system access, so defaults are checked with it at registration.
+func (c constraints) rangeViolation(value reflect.Value) string {
+	if c.min.IsValid() && compareNumbers(value, c.min) < 0 {
+		return fmt.Sprintf("must be at least %v", c.min.Interface())
+	}
+	if c.max.IsValid() && compareNumbers(value, c.max) > 0 {
+		return fmt.Sprintf("must be at most %v", c.max.Interface())
+	}
+	if c.pattern != nil && !c.pattern.MatchString(value.String()) {
+		return fmt.Sprintf("must match %s", c.pattern)
+	}
+	return ""
+}
+
+// violation describes how value breaks the constraints, or returns "" when it satisfies them.
+func (c constraints) violation(value reflect.Value) string {
+	if msg := c.rangeViolation(value); msg != "" {
+		return msg
+	}
+	if c.exists != "" {
+		info, err := os.Stat(value.String())
+		switch {
+		case err != nil && os.IsNotExist(err):
+			return c.exists + " does not exist"
+		case err != nil:
+			return err.Error()
+		case c.exists == "file" && info.IsDir():
+			return "is a directory, expected a file"
+		case c.exists == "dir" && !info.IsDir():
+			return "is not a directory"
+		}
+	}
+	return ""
+}
+
+// violations checks the value, or every element of a slice, and appends a message per broken constraint to problems.
+func (c constraints) violations(problems []string, subject string, value reflect.Value) []string {
+	if value.Kind() == reflect.Slice && !isCustomType(value.Type()) {
+		for i := 0; i < value.Len(); i++ {
+			problems = c.violations(problems, subject, value.Index(i))
+		}
+		return problems
+	}
+	if msg := c.violation(value); msg != "" {
+		problems = append(problems, fmt.Sprintf("invalid value for %s: %v (%s)", subject, value.Interface(), msg))
+	}
+	return problems
+}
+
+// checkConstraints runs after the arguments and options are bound and reports every value the user
+// gave that breaks its constraints in one usage error. Defaults are checked by validateCliTags,
+// and arguments or options left unset keep their zero value unchecked.
+func (t *implCliApplication) checkConstraints(flagSet *pflag.FlagSet, argDefs []argInfo, options map[string]optInfo, cmdValue reflect.Value, argValues []string, cmd CliCommand, stack []string) error {
+	var problems []string
+
+	variadic := false
+	for _, arg := range argDefs {
+		variadic = variadic || arg.variadic
+	}
+	for i, arg := range argDefs {
+		// Arguments around a variadic one are all bound, see bindArguments
+		if variadic || i < len(argValues) {
+			problems = arg.constraints.violations(problems, "argument "+arg.name, cmdValue.Field(arg.position))
+		}
+	}
+
+	flagSet.VisitAll(func(f *pflag.Flag) {
+		opt, ok := options[f.Name]
+		if !ok || !optionSupplied(flagSet, f.Name, opt) {
+			return
+		}
+		subject := "option --" + f.Name
+		if !flagSet.Changed(f.Name) {
+			_, from, _ := opt.fallback()
+			subject += from
+		}
+		problems = opt.constraints.violations(problems, subject, opt.field)
+	})
+
+	if len(problems) == 0 {
+		return nil
+	}
+	t.Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
+	return usageErrorf("%s", strings.Join(problems, "; "))
+}
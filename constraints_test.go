@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ─── constraints ─────────────────────────────────────────────────────────────
+
+func TestConstraints_Satisfied(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONSTRAINED_PORT", "8080")
+
+	cmd := &constrainedCmd{}
+	args := []string{"serve", "web", file, "--ratio=1", "--timeout=5s", "--dir=" + dir, "--weight=0,10"}
+	if err := Run(Args(args), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmd.ran || cmd.Port != 8080 || len(cmd.Files) != 1 {
+		t.Errorf("expected command to run with port 8080 and one file, got %+v", cmd)
+	}
+}
+
+func TestConstraints_UnsetValuesUnchecked(t *testing.T) {
+	t.Setenv("CONSTRAINED_PORT", "")
+	cmd := &constrainedCmd{}
+	if err := Run(Args([]string{"serve", "web"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmd.ran || cmd.Port != 0 {
+		t.Errorf("expected command to run with zero port, got %+v", cmd)
+	}
+}
+
+func TestConstraints_Violations(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CONSTRAINED_PORT", "0")
+
+	var out bytes.Buffer
+	cmd := &constrainedCmd{}
+	args := []string{"serve", "Web", dir, filepath.Join(dir, "missing"), "--ratio=1.5", "--timeout=10ms", "--dir=" + filepath.Join(dir, "none"), "--weight=3,11"}
+	err := Run(Args(args), Stdout(&out), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if cmd.ran {
+		t.Error("command should not run")
+	}
+	for _, want := range []string{
+		"invalid value for argument name: Web (must match ^[a-z]+$)",
+		"invalid value for argument files: " + dir + " (is a directory, expected a file)",
+		"invalid value for argument files: " + filepath.Join(dir, "missing") + " (file does not exist)",
+		"invalid value for option --dir: " + filepath.Join(dir, "none") + " (dir does not exist)",
+		"invalid value for option --port from $CONSTRAINED_PORT: 0 (must be at least 1)",
+		"invalid value for option --ratio: 1.5 (must be at most 1)",
+		"invalid value for option --timeout: 10ms (must be at least 1s)",
+		"invalid value for option --weight: 11 (must be at most 10)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+	if strings.Count(err.Error(), "invalid value") != 8 {
+		t.Errorf("expected 8 violations in one error, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Usage:") {
+		t.Errorf("expected usage line, got:\n%s", out.String())
+	}
+}
+
+func TestConstraints_VariadicRange(t *testing.T) {
+	cmd := &portsCmd{}
+	if err := Run(Args([]string{"open", "80", "65535"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmd.ran || len(cmd.Ports) != 2 {
+		t.Errorf("expected two ports, got %+v", cmd)
+	}
+
+	err := Run(Args([]string{"open", "0", "80", "70000"}), Stdout(&bytes.Buffer{}), Beans(&portsCmd{}))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	for _, want := range []string{
+		"invalid value for argument ports: 0 (must be at least 1)",
+		"invalid value for argument ports: 70000 (must be at most 65535)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+
+	err = Run(Args([]string{"open", "1", "2", "3", "4"}), Stdout(&bytes.Buffer{}), Beans(&portsCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "argument 'ports' expects at most 3 values, got 4") {
+		t.Errorf("expected arity error, got: %v", err)
+	}
+}
+
+func TestValidateCliTags_Constraints(t *testing.T) {
+	type badConstraintsCmd struct {
+		Name  string  `cli:"argument=name,pattern=[a-z"`
+		Port  int     `cli:"option=port,min=10,max=1"`
+		Size  uint8   `cli:"option=size,max=300"`
+		Ratio float64 `cli:"option=ratio,max=1,default=2"`
+		Code  string  `cli:"option=code,pattern=^[0-9]+$,default=abc"`
+		Path  string  `cli:"option=path,exists=link"`
+		Count int     `cli:"option=count,exists=file"`
+	}
+	err := validateCliTags(&badConstraintsCmd{})
+	if err == nil {
+		t.Fatal("expected error for constraint tags")
+	}
+	for _, want := range []string{
+		`badConstraintsCmd.Name: invalid pattern "[a-z"`,
+		"badConstraintsCmd.Port: max 1 is less than min 10",
+		`badConstraintsCmd.Size: invalid max "300" for uint8`,
+		`badConstraintsCmd.Ratio: default "2" must be at most 1`,
+		`badConstraintsCmd.Code: default "abc" must match ^[0-9]+$`,
+		`badConstraintsCmd.Path: invalid exists "link", expected file or dir`,
+		"badConstraintsCmd.Count: exists= applies only to string fields",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
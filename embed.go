@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"reflect"
+	"strings"
+)
+
+// embedTagKeys lists the keys accepted in the cli tag of a field embedding a reusable option set.
+var embedTagKeys = map[string]bool{
+	"embed":     true,
+	"prefix":    true,
+	"envprefix": true,
+	"help":      true,
+}
+
+// cliField is a field carrying a cli tag, declared on a command or in a struct it embeds with the
+// embed tag. Index is the path from the command struct, see reflect.Value.FieldByIndex.
+type cliField struct {
+	reflect.StructField
+
+	// path names the field from the command struct, e.g. DB.Host
+	path string
+
+	// tagParts has the prefix= and envprefix= of the enclosing embed tags applied
+	tagParts map[string]string
+
+	// err reports a malformed tag, tagParts is empty then
+	err error
+
+	// heading groups the options of an embedded struct in help, empty for the command's own fields
+	heading string
+}
+
+// embedded reports whether the field is declared in an embedded struct rather than on the command.
+func (f cliField) embedded() bool {
+	return len(f.Index) > 1
+}
+
+// cliFields returns the fields of typ carrying a cli tag in declaration order. Struct fields tagged
+// embed are listed themselves and followed by their own fields, with prefix= added to option names,
+// xor= groups and requires= names and envprefix= added to env= variables; prefixes of nested embed
+// tags add up. Embedded options are shown under the help= of the embed tag, or else the heading
+// of the enclosing embedded struct or the field name.
+// The struct may be embedded anonymously even when its type is unexported, its exported fields
+// are still settable through reflection.
+func cliFields(typ reflect.Type) []cliField {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return appendCliFields(nil, typ, nil, "", "", "", "")
+}
+
+func appendCliFields(fields []cliField, typ reflect.Type, index []int, path, prefix, envPrefix, heading string) []cliField {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		cliTag := field.Tag.Get("cli")
+		if cliTag == "" {
+			continue
+		}
+
+		f := cliField{StructField: field, path: path + field.Name, heading: heading}
+		f.Index = append(append([]int(nil), index...), i)
+		f.tagParts, f.err = splitCliTag(cliTag)
+		if f.err != nil {
+			fields = append(fields, f)
+			continue
+		}
+		applyPrefixes(f.tagParts, prefix, envPrefix)
+		fields = append(fields, f)
+
+		if _, ok := f.tagParts["embed"]; ok && field.Type.Kind() == reflect.Struct && (field.IsExported() || field.Anonymous) {
+			embedHeading := f.tagParts["help"]
+			if embedHeading == "" {
+				embedHeading = heading
+			}
+			if embedHeading == "" {
+				embedHeading = field.Name
+			}
+			fields = appendCliFields(fields, field.Type, f.Index, f.path+".",
+				prefix+f.tagParts["prefix"], envPrefix+f.tagParts["envprefix"], embedHeading)
+		}
+	}
+	return fields
+}
+
+// applyPrefixes renames the option, env variable, xor group and required options of an embedded field.
+func applyPrefixes(tagParts map[string]string, prefix, envPrefix string) {
+	if prefix == "" && envPrefix == "" {
+		return
+	}
+	if _, ok := tagParts["embed"]; ok {
+		return
+	}
+	if name, ok := tagParts["option"]; ok {
+		tagParts["option"] = prefix + name
+	}
+	if env, ok := tagParts["env"]; ok {
+		tagParts["env"] = envPrefix + env
+	}
+	if group, ok := tagParts["xor"]; ok {
+		tagParts["xor"] = prefix + group
+	}
+	if requires := parseRequires(tagParts); requires != nil {
+		for i, name := range requires {
+			requires[i] = prefix + name
+		}
+		tagParts["requires"] = strings.Join(requires, "|")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// ─── embedded option sets ────────────────────────────────────────────────────
+
+func TestEmbed_PrefixedOptions(t *testing.T) {
+	t.Setenv("DB_PORT", "")
+	t.Setenv("CACHE_TLS_CERT", "")
+	cmd := &migrateCmd{}
+	args := []string{"migrate", "v2", "--db-host=db.local", "--db-port=6432", "--cache-addr=redis:6379", "--cache-tls-cert=c.pem", "--dry-run"}
+	if err := Run(Args(args), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Host != "db.local" || cmd.Port != 6432 || cmd.Cache.Addr != "redis:6379" || cmd.Cache.TLS.Cert != "c.pem" {
+		t.Errorf("expected embedded options bound, got %+v", cmd)
+	}
+	if cmd.Target != "v2" || !cmd.DryRun {
+		t.Errorf("expected own argument and option bound, got %+v", cmd)
+	}
+}
+
+func TestEmbed_DefaultsAndEnvPrefix(t *testing.T) {
+	t.Setenv("DB_PORT", "7000")
+	t.Setenv("CACHE_TLS_CERT", "env.pem")
+	t.Setenv("PORT", "9999")
+	cmd := &migrateCmd{}
+	if err := Run(Args([]string{"migrate"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Host != "localhost" || cmd.Port != 7000 || cmd.Cache.TLS.Cert != "env.pem" {
+		t.Errorf("expected default host, port from $DB_PORT and cert from $CACHE_TLS_CERT, got %+v", cmd)
+	}
+}
+
+func TestEmbed_PrefixedRelationsAndConstraints(t *testing.T) {
+	t.Setenv("DB_PORT", "")
+	t.Setenv("DB_PASSWORD", "")
+	err := Run(Args([]string{"migrate", "--db-user=bob", "--db-port=0"}), Stdout(&bytes.Buffer{}), Beans(&migrateCmd{}))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "option '--db-user' requires '--db-password'") {
+		t.Errorf("expected prefixed requires= in error, got: %v", err)
+	}
+
+	err = Run(Args([]string{"migrate", "--db-port=0"}), Stdout(&bytes.Buffer{}), Beans(&migrateCmd{}))
+	if !strings.Contains(err.Error(), "invalid value for option --db-port: 0 (must be at least 1)") {
+		t.Errorf("expected prefixed option in constraint error, got: %v", err)
+	}
+}
+
+func TestEmbed_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"migrate", "--help"}), Stdout(&out), Beans(&migrateCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	help := out.String()
+	for _, want := range []string{
+		"Options:\n  --dry-run  Print the plan only\n",
+		"Database options:\n  --db-host  Database host [default: localhost] [$DB_HOST]\n",
+		"  --db-user  Database user [requires --db-password]\n",
+		"Cache:\n  --cache-addr  Cache address\n  --cache-tls-cert  Certificate file [$CACHE_TLS_CERT]\n",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in help, got:\n%s", want, help)
+		}
+	}
+	if strings.Index(help, "Options:") > strings.Index(help, "Database options:") {
+		t.Errorf("expected the command's own options first, got:\n%s", help)
+	}
+}
+
+func TestValidateCliTags_Embed(t *testing.T) {
+	type inner struct {
+		Name string `cli:"argument=name"`
+		Host string `cli:"option=host"`
+	}
+	type badEmbedCmd struct {
+		A     inner  `cli:"embed,prefix=a-,heading=A"`
+		B     inner  `cli:"embed"`
+		Host  string `cli:"option=host"`
+		Count int    `cli:"embed"`
+	}
+	err := validateCliTags(&badEmbedCmd{})
+	if err == nil {
+		t.Fatal("expected error for embed tags")
+	}
+	for _, want := range []string{
+		`badEmbedCmd.A: unknown key "heading" in embed tag`,
+		"badEmbedCmd.A.Name: arguments are not supported in embedded structs",
+		"badEmbedCmd.Host: duplicate option --host, already declared by B.Host",
+		"badEmbedCmd.Count: embed applies only to struct fields, got int",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
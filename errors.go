@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// Conventional process exit codes used by Main.
+const (
+	// ExitCodeError is used for runtime errors returned by commands.
+	ExitCodeError = 1
+	// ExitCodeUsage is used for usage errors: unknown commands or flags, missing or invalid arguments.
+	ExitCodeUsage = 2
+	// ExitCodeInterrupted is used when the command fails after SIGINT cancelled its context (128 + SIGINT).
+	ExitCodeInterrupted = 130
+)
+
+// UsageError reports a command line that does not match the declared groups, commands,
+// arguments or options. Main exits with ExitCodeUsage for it.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// ExitError lets a command choose the process exit code. Main exits with Code and,
+// when Err is nil, prints nothing.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// usageErrorf formats a UsageError.
+func usageErrorf(format string, args ...interface{}) error {
+	return &UsageError{Err: xerrors.Errorf(format, args...)}
+}
+
+// ExitCode maps an error returned by Run to a process exit code:
+// 0 for nil, the code of an ExitError, ExitCodeUsage for a UsageError and ExitCodeError otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitCodeUsage
+	}
+	return ExitCodeError
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// exitCmd returns an ExitError with a custom code.
+type exitCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+}
+
+func (c *exitCmd) Command() string        { return "exit" }
+func (c *exitCmd) Help() (string, string) { return "Exit with code 3.", "" }
+func (c *exitCmd) Run(_ context.Context) error {
+	return &ExitError{Code: 3, Err: xerrors.New("partial failure")}
+}
+
+// signalCmd interrupts its own process and fails once the context is cancelled.
+type signalCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Code   int      `cli:"option=code,help=Exit code to return after the signal"`
+}
+
+func (c *signalCmd) Command() string        { return "signal" }
+func (c *signalCmd) Help() (string, string) { return "Interrupt itself.", "" }
+func (c *signalCmd) Run(ctx context.Context) error {
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+	if err := proc.Signal(os.Interrupt); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		return xerrors.New("context not cancelled")
+	}
+	if c.Code != 0 {
+		return &ExitError{Code: c.Code, Err: ctx.Err()}
+	}
+	return ctx.Err()
+}
+
+// ─── ExitCode ────────────────────────────────────────────────────────────────
+
+func TestExitCode_Mapping(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{xerrors.New("runtime"), ExitCodeError},
+		{usageErrorf("unknown flag"), ExitCodeUsage},
+		{&ExitError{Code: 42}, 42},
+		{xerrors.Errorf("wrapped: %w", &ExitError{Code: 7}), 7},
+		{&ExitError{Code: ExitCodeInterrupted, Err: context.Canceled}, ExitCodeInterrupted},
+	}
+	for _, c := range cases {
+		if got := ExitCode(c.err); got != c.want {
+			t.Errorf("ExitCode(%v): expected %d, got %d", c.err, c.want, got)
+		}
+	}
+}
+
+func TestExitError_Message(t *testing.T) {
+	if msg := (&ExitError{Code: 4}).Error(); msg != "exit status 4" {
+		t.Errorf("expected 'exit status 4', got %q", msg)
+	}
+	inner := xerrors.New("inner")
+	err := &ExitError{Code: 4, Err: inner}
+	if err.Error() != "inner" || !errors.Is(err, inner) {
+		t.Errorf("expected ExitError to wrap inner error, got %v", err)
+	}
+}
+
+// ─── Run: usage errors ───────────────────────────────────────────────────────
+
+func TestRun_UsageErrors(t *testing.T) {
+	cases := map[string][]string{
+		"unknown command": {"shp"},
+		"unknown flag":    {"ship", "new", "--bogus", "x"},
+		"missing arg":     {"ship", "new"},
+		"invalid int":     {"ship", "setspeed", "fast"},
+	}
+	for name, args := range cases {
+		err := Run(Args(args), Stdout(io.Discard), Stderr(io.Discard), Beans(&shipGroup{}, &newShipCmd{}, &setSpeedCmd{}))
+		var usageErr *UsageError
+		if !errors.As(err, &usageErr) {
+			t.Errorf("%s: expected UsageError, got %v", name, err)
+		}
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%s: expected exit code %d, got %d", name, ExitCodeUsage, ExitCode(err))
+		}
+	}
+}
+
+func TestRun_RuntimeError_NotUsageError(t *testing.T) {
+	err := Run(Args([]string{"ship", "fail"}), Beans(&shipGroup{}, &failCmd{}))
+	var usageErr *UsageError
+	if err == nil || errors.As(err, &usageErr) {
+		t.Errorf("expected runtime error, got %v", err)
+	}
+	if ExitCode(err) != ExitCodeError {
+		t.Errorf("expected exit code %d, got %d", ExitCodeError, ExitCode(err))
+	}
+}
+
+func TestRun_ExitError_FromCommand(t *testing.T) {
+	err := Run(Args([]string{"exit"}), Beans(&exitCmd{}))
+	if ExitCode(err) != 3 {
+		t.Errorf("expected exit code 3, got %d (%v)", ExitCode(err), err)
+	}
+}
+
+func TestRun_Signal_ExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting the own process is not supported on windows")
+	}
+	err := Run(Args([]string{"signal"}), Beans(&signalCmd{}))
+	if ExitCode(err) != ExitCodeInterrupted || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected exit code %d, got %d (%v)", ExitCodeInterrupted, ExitCode(err), err)
+	}
+
+	err = Run(Args([]string{"signal", "--code=5"}), Beans(&signalCmd{}))
+	if ExitCode(err) != 5 {
+		t.Errorf("expected the command's exit code 5 to win over the signal, got %d (%v)", ExitCode(err), err)
+	}
+}
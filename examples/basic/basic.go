@@ -31,12 +31,12 @@ func (cmd *ShipNew) Run(ctx context.Context) error {
 }
 
 type ShipMove struct {
-	Parent  cligo.CliGroup `cli:"group=cli"`
-	Ship    string         `cli:"argument=ship"`
-	X       float64        `cli:"argument=x"`
-	Y       float64        `cli:"argument=y"`
-	Speed   int            `cli:"option=speed,default=10,help=Speed in knots."`
-	Verbose bool           `cli:"option=verbose,default=false,help=Print verbose output."`
+	Parent cligo.CliGroup       `cli:"group=cli"`
+	Ship   string               `cli:"argument=ship"`
+	X      float64              `cli:"argument=x"`
+	Y      float64              `cli:"argument=y"`
+	Speed  int                  `cli:"option=speed,default=10,help=Speed in knots."`
+	App    cligo.CliApplication `inject:""`
 }
 
 func (cmd *ShipMove) Command() string {
@@ -48,14 +48,22 @@ func (cmd *ShipMove) Help() (string, string) {
 }
 
 func (cmd *ShipMove) Run(ctx context.Context) error {
-	if cmd.Verbose {
-		cligo.Echo("Moving ship %s to %v,%v with speed %d (verbose mode)", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
+	if cmd.App.Verbose() {
+		cmd.App.Echo("Moving ship %s to %v,%v with speed %d (verbose mode)", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
 	} else {
-		cligo.Echo("Moving ship %s to %v,%v with speed %d", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
+		cmd.App.Echo("Moving ship %s to %v,%v with speed %d", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
 	}
 	return nil
 }
 
+// beans returns the commands of the application.
+func beans() []interface{} {
+	return []interface{}{
+		&ShipNew{},
+		&ShipMove{},
+	}
+}
+
 func main() {
 
 	banner := `
@@ -63,11 +71,6 @@ This is the basic CLI application example
 based on simple commands related to ship movement.
 `
 
-	beans := []interface{}{
-		&ShipNew{},
-		&ShipMove{},
-	}
-
-	cligo.Main(cligo.Name("basic"), cligo.Title("Basic CliGo Application"), cligo.Help(banner), cligo.Version("1.0.0"), cligo.Build("001"), cligo.Beans(beans...))
+	cligo.Main(cligo.Name("basic"), cligo.Title("Basic CliGo Application"), cligo.Help(banner), cligo.Version("1.0.0"), cligo.Build("001"), cligo.Beans(beans()...))
 
 }
@@ -42,12 +42,12 @@ func (cmd *ShipNew) Run(ctx context.Context) error {
 }
 
 type ShipMove struct {
-	Parent  cligo.CliGroup `cli:"group=ship"`
-	Ship    string         `cli:"argument=ship"`
-	X       float64        `cli:"argument=x"`
-	Y       float64        `cli:"argument=y"`
-	Speed   int            `cli:"option=speed,short=-s,default=10,help=Speed in knots."`
-	Verbose bool           `cli:"option=verbose,default=false,help=Print verbose output."`
+	Parent cligo.CliGroup       `cli:"group=ship"`
+	Ship   string               `cli:"argument=ship"`
+	X      float64              `cli:"argument=x"`
+	Y      float64              `cli:"argument=y"`
+	Speed  int                  `cli:"option=speed,short=-s,default=10,help=Speed in knots."`
+	App    cligo.CliApplication `inject:""`
 }
 
 func (cmd *ShipMove) Command() string {
@@ -59,10 +59,10 @@ func (cmd *ShipMove) Help() (string, string) {
 }
 
 func (cmd *ShipMove) Run(ctx context.Context) error {
-	if cmd.Verbose {
-		cligo.Echo("Moving ship %s to %v,%v with speed %d (verbose mode)", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
+	if cmd.App.Verbose() {
+		cmd.App.Echo("Moving ship %s to %v,%v with speed %d (verbose mode)", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
 	} else {
-		cligo.Echo("Moving ship %s to %v,%v with speed %d", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
+		cmd.App.Echo("Moving ship %s to %v,%v with speed %d", cmd.Ship, cmd.X, cmd.Y, cmd.Speed)
 	}
 	return nil
 }
@@ -140,9 +140,9 @@ func (cmd *Remove) Run(ctx context.Context) error {
 	return nil
 }
 
-func main() {
-
-	beans := []interface{}{
+// beans returns the groups and commands of the application.
+func beans() []interface{} {
+	return []interface{}{
 		&Ship{},
 		&ShipNew{},
 		&ShipMove{},
@@ -151,6 +151,9 @@ func main() {
 		&Set{},
 		&Remove{},
 	}
+}
+
+func main() {
 
 	help := `Naval Fate.
 		This is the docopt example adopted to cligo but with some actual
@@ -158,6 +161,6 @@ func main() {
 		is not all that interesting.
 	`
 
-	cligo.Main(cligo.Beans(beans...), cligo.Help(help))
+	cligo.Main(cligo.Beans(beans()...), cligo.Help(help))
 
 }
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"go.arpabet.com/cligo"
+	"go.arpabet.com/cligo/cligotest"
+)
+
+func TestBeans_Register(t *testing.T) {
+	if res := cligotest.New(t, cligo.Beans(beans()...)).Run("--help"); res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+}
+
+func TestShipMove_Verbose(t *testing.T) {
+	res := cligotest.New(t, cligo.Beans(beans()...)).Run("ship", "move", "enterprise", "3", "4", "-s", "25", "--verbose")
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+	if !strings.Contains(res.Stdout, "Moving ship enterprise to 3,4 with speed 25 (verbose mode)") {
+		t.Errorf("expected verbose move output, got %q", res.Stdout)
+	}
+}
@@ -64,6 +64,15 @@ func (cmd *RemoveUser) Run(ctx context.Context) error {
 	return nil
 }
 
+// beans returns the groups and commands of the application.
+func beans() []interface{} {
+	return []interface{}{
+		&User{},
+		&AddUser{},
+		&RemoveUser{},
+	}
+}
+
 func main() {
 
 	banner := `
@@ -74,12 +83,6 @@ based on simple commands related to user management.
 	properties := glue.NewProperties()
 	properties.Set("profiles.active", "dev")
 
-	beans := []interface{}{
-		&User{},
-		&AddUser{},
-		&RemoveUser{},
-	}
-
-	cligo.Main(cligo.Help(banner), cligo.Version("1.0.0"), cligo.Properties(properties), cligo.Beans(beans...))
+	cligo.Main(cligo.Help(banner), cligo.Version("1.0.0"), cligo.Properties(properties), cligo.Beans(beans()...))
 
 }
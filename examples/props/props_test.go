@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package main
+
+import (
+	"testing"
+
+	"go.arpabet.com/cligo"
+	"go.arpabet.com/cligo/cligotest"
+)
+
+func TestBeans_Register(t *testing.T) {
+	if res := cligotest.New(t, cligo.Beans(beans()...)).Run("--help"); res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+}
@@ -7,52 +7,66 @@ package cligo
 
 import (
 	"context"
-	"os"
-	"strings"
+	"errors"
+
+	"github.com/spf13/pflag"
 
 	"go.arpabet.com/glue"
-	"golang.org/x/xerrors"
 )
 
 // Execute parses arguments and runs the appropriate command
 func (t *implCliApplication) Execute(ctx context.Context, c glue.Container) error {
 
-	if len(os.Args) < 2 {
+	// Global flags were parsed in New, see globalFlags.split
+	_, args := t.globalFlags.split(t.args)
+
+	if len(args) == 0 {
 		t.printHelp(RootGroup, nil)
 		return nil
 	}
 
 	// Check for version flag
 	if t.version != "" {
-		if os.Args[1] == "--version" || os.Args[1] == "-v" {
+		if t.globalFlags.version.is(args[0]) {
 			name := t.name
 			if t.title != "" {
 				name = t.title
 			}
 			if t.build != "" {
-				Echo("%s Version %s Build %s", name, t.version, t.build)
+				t.Echo("%s Version %s Build %s", name, t.version, t.build)
 			} else {
-				Echo("%s Version %s", name, t.version)
+				t.Echo("%s Version %s", name, t.version)
 			}
 			if t.help != "" {
-				Echo(t.help)
+				t.Echo(t.help)
 			}
 			return nil
 		}
 	}
 
 	// Check for help flag
-	if os.Args[1] == "--help" || os.Args[1] == "-h" {
+	if args[0] == "--help" || args[0] == "-h" {
 		t.printHelp(RootGroup, nil)
 		return nil
 	}
 
+	// Hidden built-in completion command, unless the application defines its own
+	if args[0] == completionCommand && t.findCommand(RootGroup, completionCommand) == nil && t.findGroup(RootGroup, completionCommand) == nil {
+		return t.printCompletion(args[1:])
+	}
+
+	// Hidden entrypoint used by the completion scripts for dynamic candidates
+	if args[0] == completeCommand {
+		return t.complete(ctx, c, args[1:])
+	}
+
 	var stack []string
-	return t.parseAndExecute(ctx, c, RootGroup, os.Args[1:], stack)
+	return t.parseAndExecute(ctx, c, RootGroup, args, stack, nil)
 }
 
-// parseAndExecute recursively parses arguments and executes the appropriate command
-func (t *implCliApplication) parseAndExecute(ctx context.Context, c glue.Container, currentGroup string, args []string, stack []string) error {
+// parseAndExecute recursively parses arguments and executes the appropriate command.
+// Options of the groups passed on the way are collected in groupArgs and parsed with the command's own.
+func (t *implCliApplication) parseAndExecute(ctx context.Context, c glue.Container, currentGroup string, args []string, stack []string, groupArgs []string) error {
 	if len(args) == 0 {
 		t.printHelp(currentGroup, stack)
 		return nil
@@ -66,7 +80,14 @@ func (t *implCliApplication) parseAndExecute(ctx context.Context, c glue.Contain
 			return nil
 		}
 		stack = append(stack, args[0])
-		return t.parseAndExecute(ctx, c, matchedGroup.Group(), args[1:], stack)
+		rest, groupFlags, err := t.splitGroupArgs(matchedGroup, args[1:], stack)
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return t.parseAndExecute(ctx, c, matchedGroup.Group(), rest, stack, append(groupArgs, groupFlags...))
 	}
 
 	// Check if the first argument is a command (by name or alias)
@@ -77,7 +98,7 @@ func (t *implCliApplication) parseAndExecute(ctx context.Context, c glue.Contain
 			return nil
 		}
 		stack = append(stack, args[0])
-		return t.executeCommand(ctx, c, matchedCmd, args[1:], stack)
+		return t.executeCommand(ctx, c, matchedCmd, append(groupArgs, args[1:]...), stack)
 	}
 
 	// Check if the first argument is a know option
@@ -92,33 +113,37 @@ func (t *implCliApplication) parseAndExecute(ctx context.Context, c glue.Contain
 		return nil
 	}
 
-	if args[0] == "--profile" || args[0] == "-p" || strings.HasPrefix(args[0], "--profile=") || strings.HasPrefix(args[0], "-p=") ||
-		args[0] == "--config" || args[0] == "-c" || strings.HasPrefix(args[0], "--config=") || strings.HasPrefix(args[0], "-c=") {
-		// Skip --profile/--config (and short forms) and its value, then continue parsing
-		skip := 1
-		if (args[0] == "--profile" || args[0] == "-p" || args[0] == "--config" || args[0] == "-c") && len(args) > 1 {
-			skip = 2
-		}
-		if skip >= len(args) {
-			t.printHelp(currentGroup, stack)
-			return nil
-		}
-		return t.parseAndExecute(ctx, c, currentGroup, args[skip:], stack)
+	t.printHelp(currentGroup, stack)
+	if suggestion := t.suggest(currentGroup, args[0]); suggestion != "" {
+		return usageErrorf("unknown command or group: %s. Did you mean %q?", args[0], suggestion)
 	}
+	return usageErrorf("unknown command or group: %s", args[0])
+}
 
-	// Skip -D/--property overrides (parsed up front into a property resolver),
-	// then continue locating the command.
-	if matched, skip := globalPropertyArgSkip(args); matched {
-		if skip >= len(args) {
-			t.printHelp(currentGroup, stack)
-			return nil
-		}
-		return t.parseAndExecute(ctx, c, currentGroup, args[skip:], stack)
+// splitGroupArgs separates the options of group and the groups above it, given before the next group or
+// command name, from the rest of args. It returns pflag.ErrHelp when the group help was printed instead.
+func (t *implCliApplication) splitGroupArgs(group CliGroup, args []string, stack []string) (rest []string, groupFlags []string, err error) {
+	flagSet := pflag.NewFlagSet(group.Group(), pflag.ContinueOnError)
+	flagSet.SetOutput(t.stderr)
+	flagSet.Usage = func() { t.printHelp(group.Group(), stack) }
+	flagSet.SetInterspersed(false)
+	if _, err := t.addGroupOptions(flagSet, t.groupChain(group.Group())); err != nil {
+		return nil, nil, err
+	}
+	if !flagSet.HasFlags() {
+		return args, nil, nil
 	}
 
-	t.printHelp(currentGroup, stack)
-	if suggestion := t.suggest(currentGroup, args[0]); suggestion != "" {
-		return xerrors.Errorf("unknown command or group: %s. Did you mean %q?", args[0], suggestion)
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil, nil, err
+		}
+		return nil, nil, &UsageError{Err: err}
+	}
+	rest = flagSet.Args()
+	groupFlags = args[:len(args)-len(rest)]
+	if n := len(groupFlags); n > 0 && groupFlags[n-1] == "--" {
+		groupFlags = groupFlags[:n-1]
 	}
-	return xerrors.Errorf("unknown command or group: %s", args[0])
+	return rest, groupFlags, nil
 }
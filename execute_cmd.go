@@ -7,6 +7,7 @@ package cligo
 
 import (
 	"context"
+	"errors"
 	"reflect"
 
 	"github.com/spf13/pflag"
@@ -22,19 +23,29 @@ func (t *implCliApplication) executeCommand(ctx context.Context, c glue.Containe
 
 	// Prepare a custom flag set
 	flagSet := pflag.NewFlagSet(cmd.Command(), pflag.ContinueOnError)
+	flagSet.SetOutput(t.stderr)
 	flagSet.Usage = func() { t.printCommandHelp(cmd, stack) }
 
 	// First pass: identify arguments and register options
-	argDefs, options, envVars := t.identifyArgumentsAndOptions(cmdType, cmdValue, flagSet)
+	argDefs, options := t.identifyArgumentsAndOptions(cmdType, cmdValue, flagSet)
+
+	// Options of the groups above the command are accepted after its name too
+	groupOptions, err := t.addGroupOptions(flagSet, t.groupChain(extractParentInfo(cmd).group))
+	if err != nil {
+		return err
+	}
+	for name, opt := range groupOptions {
+		options[name] = opt
+	}
 
 	// Add help option
 	isHelp := flagSet.BoolP("help", "h", false, "Print help")
 	isVerbose := flagSet.Bool("verbose", false, "Verbose output")
 
 	// Parse flags
-	err := flagSet.Parse(args)
+	err = flagSet.Parse(args)
 	if err != nil {
-		return err
+		return &UsageError{Err: err}
 	}
 
 	argValues := flagSet.Args()
@@ -55,14 +66,36 @@ func (t *implCliApplication) executeCommand(ctx context.Context, c glue.Containe
 		return err
 	}
 
-	// Set option values: explicit flag > env var > default.
-	t.setOptionValues(flagSet, options, envVars)
+	// Set option values: explicit flag > env var > property > default.
+	resolveOptionProperties(c.Properties(), options)
+	err = t.setOptionValues(flagSet, options, cmd, stack)
+	if err != nil {
+		return err
+	}
+
+	// Check min=, max=, pattern= and exists= before the command sees the values
+	err = t.checkConstraints(flagSet, argDefs, options, cmdValue, argValues, cmd, stack)
+	if err != nil {
+		return err
+	}
+
+	// Let the command check rules across its arguments and options
+	if validator, ok := cmd.(CliValidator); ok {
+		if err = validator.Validate(ctx); err != nil {
+			t.Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
+			var usageErr *UsageError
+			if !errors.As(err, &usageErr) {
+				err = &UsageError{Err: err}
+			}
+			return err
+		}
+	}
 
 	cmdBeans, ok := t.commandBeans[cmd.Command()]
 	if ok && len(cmdBeans) > 0 {
 		child, err := c.Extend(cmdBeans...)
 		if err != nil {
-			Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
+			t.Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
 			return xerrors.Errorf("fail to initialize '%s' command scope context, %v", cmd.Command(), err)
 		}
 		defer child.Close()
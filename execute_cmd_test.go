@@ -6,6 +6,7 @@
 package cligo
 
 import (
+	"bytes"
 	"os"
 	"strings"
 	"testing"
@@ -94,6 +95,82 @@ func TestRun_Command_InvalidFloatArg_ReturnsError(t *testing.T) {
 	})
 }
 
+func TestRun_Command_ExtraArg_ReturnsError(t *testing.T) {
+	err := Run(Args([]string{"ship", "new", "titanic", "extra"}), Stdout(&bytes.Buffer{}), Beans(&shipGroup{}, &newShipCmd{}))
+	if ExitCode(err) != ExitCodeUsage || !strings.Contains(err.Error(), "unexpected argument 'extra'") {
+		t.Errorf("expected unexpected argument error, got: %v", err)
+	}
+}
+
+// ─── Run: variadic arguments ─────────────────────────────────────────────────
+
+func TestRun_VariadicArg_BeforeRequired(t *testing.T) {
+	cmd := &copyCmd{}
+	if err := Run(Args([]string{"cp", "a", "b", "c", "dir"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cmd.Src, " ") != "a b c" || cmd.Dst != "dir" {
+		t.Errorf("expected Src=[a b c] Dst=dir, got %v %q", cmd.Src, cmd.Dst)
+	}
+}
+
+func TestRun_VariadicArg_RequiredByDefault(t *testing.T) {
+	cmd := &copyCmd{}
+	err := Run(Args([]string{"cp", "dir"}), Stdout(&bytes.Buffer{}), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage || !strings.Contains(err.Error(), "missing required argument 'src'") {
+		t.Errorf("expected missing src error, got: %v", err)
+	}
+	if cmd.ran {
+		t.Error("command should not run")
+	}
+}
+
+func TestRun_VariadicArg_MinMax(t *testing.T) {
+	cmd := &sumCmd{}
+	if err := Run(Args([]string{"sum", "add"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmd.Nums) != 0 {
+		t.Errorf("expected no Nums, got %v", cmd.Nums)
+	}
+
+	cmd = &sumCmd{}
+	if err := Run(Args([]string{"sum", "add", "1", "2", "3"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmd.Nums) != 3 || cmd.Nums[2] != 3 {
+		t.Errorf("expected Nums=[1 2 3], got %v", cmd.Nums)
+	}
+
+	err := Run(Args([]string{"sum", "add", "1", "2", "3", "4"}), Stdout(&bytes.Buffer{}), Beans(&sumCmd{}))
+	if ExitCode(err) != ExitCodeUsage || !strings.Contains(err.Error(), "argument 'nums' expects at most 3 values, got 4") {
+		t.Errorf("expected max arity error, got: %v", err)
+	}
+
+	err = Run(Args([]string{"sum", "add", "1", "x"}), Stdout(&bytes.Buffer{}), Beans(&sumCmd{}))
+	if ExitCode(err) != ExitCodeUsage || !strings.Contains(err.Error(), "invalid integer for argument nums: x") {
+		t.Errorf("expected invalid element error, got: %v", err)
+	}
+}
+
+func TestRun_VariadicArg_Usage(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"cp", "--help"}), Stdout(&out), Beans(&copyCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[OPTIONS] SRC... DST") {
+		t.Errorf("expected SRC... DST in usage, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := Run(Args([]string{"sum", "--help"}), Stdout(&out), Beans(&sumCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[OPTIONS] OP [NUMS]...") || !strings.Contains(out.String(), "Numbers [0 to 3]") {
+		t.Errorf("expected optional variadic usage, got:\n%s", out.String())
+	}
+}
+
 // ─── Run: command option parsing ─────────────────────────────────────────────
 
 func TestRun_Command_IntOption_LongFlag(t *testing.T) {
@@ -289,6 +366,71 @@ func TestRun_EnvVar_FallsBackToDefault(t *testing.T) {
 	}
 }
 
+func TestRun_EnvVar_InvalidValue_ReturnsUsageError(t *testing.T) {
+	t.Setenv("TEST_CLI_PORT", "abc")
+	var out bytes.Buffer
+	cmd := &envCmd{}
+	err := Run(Args([]string{"envcmd"}), Stdout(&out), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "invalid integer for option --port from $TEST_CLI_PORT: abc") {
+		t.Errorf("expected option, env var and value in error, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Usage:") {
+		t.Errorf("expected usage line, got: %q", out.String())
+	}
+	if cmd.ran {
+		t.Error("command should not run with an invalid env value")
+	}
+}
+
+// ─── Run: required options ───────────────────────────────────────────────────
+
+func TestRun_RequiredOptions_AllMissing(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &requiredOptCmd{}
+	err := Run(Args([]string{"login"}), Stdout(&out), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "missing required options '--token', '--user'") {
+		t.Errorf("expected both missing options in error, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Usage:") {
+		t.Errorf("expected usage line, got: %q", out.String())
+	}
+	if cmd.ran {
+		t.Error("command should not run")
+	}
+}
+
+func TestRun_RequiredOptions_FlagAndEnv(t *testing.T) {
+	t.Setenv("REQ_TOKEN", "secret")
+	cmd := &requiredOptCmd{}
+	if err := Run(Args([]string{"login", "--user=alice"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Token != "secret" || cmd.User != "alice" {
+		t.Errorf("expected Token=secret User=alice, got %q %q", cmd.Token, cmd.User)
+	}
+
+	err := Run(Args([]string{"login", "--token=x"}), Stdout(&bytes.Buffer{}), Beans(&requiredOptCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "missing required option '--user'") {
+		t.Errorf("expected missing --user error, got: %v", err)
+	}
+}
+
+func TestRun_RequiredOptions_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"login", "--help"}), Stdout(&out), Beans(&requiredOptCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "API token [required] [$REQ_TOKEN]") {
+		t.Errorf("expected [required] in help, got:\n%s", out.String())
+	}
+}
+
 func TestRun_EnvVar_StringOption(t *testing.T) {
 	os.Setenv("TEST_CLI_HOST", "0.0.0.0")
 	defer os.Unsetenv("TEST_CLI_HOST")
@@ -404,6 +546,21 @@ func TestSliceOption_EnvVar_IntSlice(t *testing.T) {
 	}
 }
 
+func TestSliceOption_EnvVar_InvalidElement_ReturnsUsageError(t *testing.T) {
+	t.Setenv("APP_PORTS", "80,x")
+	cmd := &sliceEnvCmd{}
+	err := Run(Args([]string{"sliceenvcmd"}), Stdout(&bytes.Buffer{}), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "invalid integer for option --port from $APP_PORTS: x") {
+		t.Errorf("expected option, env var and element in error, got: %v", err)
+	}
+	if cmd.Ports != nil {
+		t.Errorf("expected Ports unset, got %v", cmd.Ports)
+	}
+}
+
 func TestSliceOption_CLIOverridesEnvVar(t *testing.T) {
 	t.Setenv("APP_TAGS", "from-env")
 	cmd := &sliceEnvCmd{}
@@ -416,3 +573,176 @@ func TestSliceOption_CLIOverridesEnvVar(t *testing.T) {
 		t.Errorf("expected Tags=[from-cli] (CLI overrides env), got %v", cmd.Tags)
 	}
 }
+
+// ─── Run: CliValidator ───────────────────────────────────────────────────────
+
+func TestValidate_Passes(t *testing.T) {
+	cmd := &windowCmd{}
+	if err := Run(Args([]string{"window", "--from=2024-01-01", "--to=2024-02-01"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmd.ran {
+		t.Error("expected command to run")
+	}
+}
+
+func TestValidate_Error_ReturnsUsageError(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &windowCmd{}
+	err := Run(Args([]string{"window", "--from=2024-02-01", "--to=2024-01-01"}), Stdout(&out), Beans(cmd))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+	if err.Error() != "--from must be before --to" {
+		t.Errorf("expected validator error, got: %v", err)
+	}
+	if cmd.ran {
+		t.Error("command should not run")
+	}
+	if !strings.Contains(out.String(), "Usage:") || !strings.Contains(out.String(), "--help") {
+		t.Errorf("expected usage and try-usage lines, got:\n%s", out.String())
+	}
+}
+
+// ─── Run: negatable and counted options ──────────────────────────────────────
+
+func TestNegatableOption(t *testing.T) {
+	t.Setenv("BUILD_CACHE", "")
+	for _, tc := range []struct {
+		args []string
+		env  string
+		want bool
+	}{
+		{[]string{"build"}, "", true},
+		{[]string{"build", "--no-cache"}, "", false},
+		{[]string{"build", "--cache=false"}, "", false},
+		{[]string{"build", "--no-cache", "--cache"}, "", true},
+		{[]string{"build", "--cache", "--no-cache"}, "", false},
+		{[]string{"build", "--no-cache=false"}, "", true},
+		{[]string{"build"}, "false", false},
+		{[]string{"build", "--cache"}, "false", true},
+	} {
+		t.Setenv("BUILD_CACHE", tc.env)
+		cmd := &buildFlagsCmd{}
+		if err := Run(Args(tc.args), Beans(cmd)); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.args, err)
+			continue
+		}
+		if cmd.Cache != tc.want {
+			t.Errorf("%v with BUILD_CACHE=%q: expected Cache=%v, got %v", tc.args, tc.env, tc.want, cmd.Cache)
+		}
+	}
+}
+
+func TestCountOption(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"build"}, 0},
+		{[]string{"build", "-v"}, 1},
+		{[]string{"build", "-vvv"}, 3},
+		{[]string{"build", "-v", "--verbosity", "-vv"}, 4},
+		{[]string{"build", "--verbosity=5"}, 5},
+	} {
+		cmd := &buildFlagsCmd{}
+		if err := Run(Args(tc.args), Beans(cmd)); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.args, err)
+			continue
+		}
+		if cmd.Verbosity != tc.want {
+			t.Errorf("%v: expected Verbosity=%d, got %d", tc.args, tc.want, cmd.Verbosity)
+		}
+	}
+}
+
+func TestNegatableAndCount_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"build", "--help"}), Stdout(&out), Beans(&buildFlagsCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"--[no-]cache  Use the build cache [default: true]", "Increase verbosity [repeatable]"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in help, got:\n%s", want, out.String())
+		}
+	}
+}
+
+// ─── Map options ─────────────────────────────────────────────────────────────
+
+func TestMapOption_Flags(t *testing.T) {
+	t.Setenv("DEPLOY_LABELS", "")
+	t.Setenv("DEPLOY_LIMITS", "")
+	cmd := &mapOptionsCmd{}
+	args := []string{"rollout", "--label", "team=core", "-l", "note=a,b=c", "--label=team=web", "--limit=cpu=2,mem=512", "--limit", "disk=10"}
+	if err := Run(Args(args), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmd.Labels) != 2 || cmd.Labels["team"] != "web" || cmd.Labels["note"] != "a,b=c" {
+		t.Errorf("expected labels team=web and note=a,b=c, got %v", cmd.Labels)
+	}
+	if len(cmd.Limits) != 3 || cmd.Limits["cpu"] != 2 || cmd.Limits["mem"] != 512 || cmd.Limits["disk"] != 10 {
+		t.Errorf("expected limits cpu=2 mem=512 disk=10, got %v", cmd.Limits)
+	}
+}
+
+func TestMapOption_Unset_ReturnsNil(t *testing.T) {
+	t.Setenv("DEPLOY_LABELS", "")
+	t.Setenv("DEPLOY_LIMITS", "")
+	cmd := &mapOptionsCmd{}
+	if err := Run(Args([]string{"rollout"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Labels != nil || cmd.Limits != nil {
+		t.Errorf("expected nil maps, got %v %v", cmd.Labels, cmd.Limits)
+	}
+}
+
+func TestMapOption_EnvVar(t *testing.T) {
+	t.Setenv("DEPLOY_LABELS", "team=core,tier=web")
+	t.Setenv("DEPLOY_LIMITS", "cpu=2, mem=512")
+	cmd := &mapOptionsCmd{}
+	if err := Run(Args([]string{"rollout", "--label=team=cli"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmd.Labels) != 1 || cmd.Labels["team"] != "cli" {
+		t.Errorf("expected Labels=map[team:cli] (CLI overrides env), got %v", cmd.Labels)
+	}
+	if len(cmd.Limits) != 2 || cmd.Limits["cpu"] != 2 || cmd.Limits["mem"] != 512 {
+		t.Errorf("expected limits from env, got %v", cmd.Limits)
+	}
+}
+
+func TestMapOption_Invalid_ReturnsUsageError(t *testing.T) {
+	for _, tc := range []struct {
+		args   []string
+		limits string
+		want   string
+	}{
+		{[]string{"rollout", "--label=team"}, "", `expected key=value, got "team"`},
+		{[]string{"rollout", "--limit=cpu=x"}, "", `invalid argument "cpu=x" for "--limit" flag`},
+		{[]string{"rollout"}, "cpu", `invalid entry for option --limit from $DEPLOY_LIMITS: expected key=value, got "cpu"`},
+		{[]string{"rollout"}, "cpu=2,mem=x", `invalid entry for option --limit from $DEPLOY_LIMITS: strconv.ParseInt: parsing "x"`},
+	} {
+		t.Setenv("DEPLOY_LABELS", "")
+		t.Setenv("DEPLOY_LIMITS", tc.limits)
+		err := Run(Args(tc.args), Stdout(&bytes.Buffer{}), Stderr(&bytes.Buffer{}), Beans(&mapOptionsCmd{}))
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%v: expected usage error, got: %v", tc.args, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: expected %q in error, got: %v", tc.args, tc.want, err)
+		}
+	}
+}
+
+func TestMapOption_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"rollout", "--help"}), Stdout(&out), Beans(&mapOptionsCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "--label key=value  Add a label [$DEPLOY_LABELS]") {
+		t.Errorf("expected map option in help, got:\n%s", out.String())
+	}
+}
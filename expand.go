@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"os"
+	"reflect"
+	"strings"
+
+	"go.arpabet.com/glue"
+	"golang.org/x/xerrors"
+)
+
+// expandedTagKeys lists the tag keys whose values may contain ${...} placeholders.
+var expandedTagKeys = []string{"default", "help"}
+
+// expandPlaceholders replaces every ${key} in s with the value of the property key, or else of the
+// environment variable key, and every ${key:fallback} with fallback when neither is set. $${ is an escape
+// for a literal ${. props may be nil.
+func expandPlaceholders(s string, props glue.Properties) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var out strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			out.WriteString(s)
+			return out.String(), nil
+		}
+		if start > 0 && s[start-1] == '$' {
+			out.WriteString(s[:start-1])
+			out.WriteString("${")
+			s = s[start+2:]
+			continue
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", xerrors.Errorf("unterminated placeholder in %q", s)
+		}
+		out.WriteString(s[:start])
+		key, fallback, hasFallback := strings.Cut(s[start+2:start+end], ":")
+		if key == "" {
+			return "", xerrors.Errorf("empty placeholder in %q", s)
+		}
+		value, ok := lookupPlaceholder(key, props)
+		switch {
+		case ok:
+			out.WriteString(value)
+		case hasFallback:
+			out.WriteString(fallback)
+		default:
+			return "", xerrors.Errorf("unresolved placeholder ${%s}", key)
+		}
+		s = s[start+end+1:]
+	}
+}
+
+// lookupPlaceholder resolves a placeholder key as a property, then as an environment variable.
+func lookupPlaceholder(key string, props glue.Properties) (string, bool) {
+	if props != nil {
+		if value, ok := props.Get(key); ok {
+			return value, true
+		}
+	}
+	return os.LookupEnv(key)
+}
+
+// expandedCliFields returns cliFields of typ with the placeholders in default= and help= expanded
+// against the container properties (allProperties) and the environment, so defaults follow the active profile and
+// config files. A placeholder that does not resolve is reported as the field's error.
+func (t *implCliApplication) expandedCliFields(typ reflect.Type) []cliField {
+	return expandCliFields(cliFields(typ), t.allProperties)
+}
+
+func expandCliFields(fields []cliField, props glue.Properties) []cliField {
+	for i, field := range fields {
+		if field.err != nil {
+			continue
+		}
+		for _, key := range expandedTagKeys {
+			value, ok := field.tagParts[key]
+			if !ok {
+				continue
+			}
+			expanded, err := expandPlaceholders(value, props)
+			if err != nil {
+				fields[i].err = xerrors.Errorf("%s=: %v", key, err)
+				break
+			}
+			field.tagParts[key] = expanded
+		}
+	}
+	return fields
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.arpabet.com/glue"
+)
+
+// ─── placeholder expansion ───────────────────────────────────────────────────
+
+func TestExpandPlaceholders(t *testing.T) {
+	t.Setenv("CLIGO_TEST_HOME", "/home/test")
+	props := glue.NewProperties()
+	props.Set("app.home", "/opt/app")
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"${app.home}/cache", "/opt/app/cache"},
+		{"${CLIGO_TEST_HOME}", "/home/test"},
+		{"${missing:fallback}", "fallback"},
+		{"${missing:}", ""},
+		{"${app.home:x} and ${CLIGO_TEST_HOME}", "/opt/app and /home/test"},
+		{"$HOME {x}", "$HOME {x}"},
+		{"$${missing}", "${missing}"},
+		{"$${app.home} is ${app.home}", "${app.home} is /opt/app"},
+	} {
+		got, err := expandPlaceholders(tc.in, props)
+		if err != nil || got != tc.want {
+			t.Errorf("expandPlaceholders(%q) = %q, %v, want %q", tc.in, got, err, tc.want)
+		}
+	}
+	for in, want := range map[string]string{
+		"${missing}":  "unresolved placeholder ${missing}",
+		"${app.home":  "unterminated placeholder",
+		"${}/x":       "empty placeholder",
+		"${:default}": "empty placeholder",
+	} {
+		if _, err := expandPlaceholders(in, props); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expandPlaceholders(%q): expected %q, got %v", in, want, err)
+		}
+	}
+}
+
+func TestPlaceholders_DefaultFromProperties(t *testing.T) {
+	t.Setenv("CACHE_USER", "")
+	cmd := &cacheDirCmd{}
+	if err := Run(Args([]string{"-Dapp.home=/srv", "cachedir"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Dir != "/srv/cache" || cmd.Port != 8080 || cmd.User != "" {
+		t.Errorf("expected expanded defaults, got %+v", cmd)
+	}
+
+	t.Setenv("CACHE_USER", "alice")
+	path := writeTempFile(t, "config.properties", "app.home = /data\nserver.port = 9000\n")
+	cmd = &cacheDirCmd{}
+	if err := Run(Args([]string{"cachedir"}), ConfigFile(path), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Dir != "/data/cache" || cmd.Port != 9000 || cmd.User != "alice" {
+		t.Errorf("expected defaults from the config file and env, got %+v", cmd)
+	}
+}
+
+func TestPlaceholders_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"-Dapp.home=/srv", "-Dserver.port=9000", "cachedir", "--help"}), Stdout(&out), Beans(&cacheDirCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"  --dir  Cache directory [default: /srv/cache]\n",
+		"  --port  Port (default 9000) [default: 9000]\n",
+		"  --template  Output template; may use ${USER}\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in help, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPlaceholders_Unresolved(t *testing.T) {
+	err := Run(Args([]string{"cachedir"}), Stdout(&bytes.Buffer{}), Beans(&cacheDirCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "cacheDirCmd.Dir: default=: unresolved placeholder ${app.home}") {
+		t.Errorf("expected unresolved placeholder error, got: %v", err)
+	}
+
+	err = Run(Args([]string{"-Dserver.port=abc", "-Dapp.home=/srv", "cachedir"}), Stdout(&bytes.Buffer{}), Beans(&cacheDirCmd{}))
+	if err == nil || !strings.Contains(err.Error(), `cacheDirCmd.Port: invalid default "abc" for int`) {
+		t.Errorf("expected invalid expanded default error, got: %v", err)
+	}
+}
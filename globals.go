@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// globalFlag names a built-in global flag. An empty long name disables the flag,
+// an empty short name leaves it without a short form.
+type globalFlag struct {
+	long  string
+	short string
+}
+
+// globalOption is an application-wide flag declared with GlobalOption. Its value is set as a
+// property with the top priority of the -D/--property overrides.
+type globalOption struct {
+	globalFlag
+	property     string
+	help         string
+	defaultValue string
+}
+
+// globalFlags are the names of the built-in global flags, see GlobalFlags, and the global options
+// the application declares, see GlobalOption.
+type globalFlags struct {
+	version  globalFlag
+	profile  globalFlag
+	config   globalFlag
+	property globalFlag
+	options  []globalOption
+}
+
+// defaultGlobalFlags returns the names the built-in global flags have unless GlobalFlags changes them.
+func defaultGlobalFlags() globalFlags {
+	return globalFlags{
+		version:  globalFlag{long: "version", short: "v"},
+		profile:  globalFlag{long: "profile", short: "p"},
+		config:   globalFlag{long: "config", short: "c"},
+		property: globalFlag{long: "property", short: "D"},
+	}
+}
+
+// is reports whether arg is exactly the long or short form of the flag.
+func (f globalFlag) is(arg string) bool {
+	if f.long == "" {
+		return false
+	}
+	return arg == "--"+f.long || f.short != "" && arg == "-"+f.short
+}
+
+// display renders the flag for help output, e.g. "-p, --profile".
+func (f globalFlag) display() string {
+	if f.short == "" {
+		return "--" + f.long
+	}
+	return "-" + f.short + ", --" + f.long
+}
+
+// match reports how many tokens of args the flag consumes when args[0] is the flag, with its value:
+// 2 when the value follows as a separate token (--flag value, -f value), 1 when it is attached
+// (--flag=value, -f=value, -fvalue). It returns 0 when args[0] is not the flag.
+func (f globalFlag) match(args []string) (value string, skip int) {
+	arg := args[0]
+	switch {
+	case f.is(arg):
+		if len(args) > 1 {
+			return args[1], 2
+		}
+		return "", 2
+	case f.long != "" && strings.HasPrefix(arg, "--"+f.long+"="):
+		return arg[len("--"+f.long+"="):], 1
+	case f.long != "" && f.short != "" && len(arg) > 2 && strings.HasPrefix(arg, "-"+f.short):
+		return strings.TrimPrefix(arg[len("-"+f.short):], "="), 1
+	}
+	return "", 0
+}
+
+// helpText describes the global option in help output.
+func (o globalOption) helpText() string {
+	help := o.help
+	if help == "" {
+		help = fmt.Sprintf("%s option", o.long)
+	}
+	if o.defaultValue != "" {
+		help += fmt.Sprintf(" [default: %s]", o.defaultValue)
+	}
+	return help + fmt.Sprintf(" [property: %s]", o.property)
+}
+
+// valueFlags lists the enabled global flags that take a value.
+func (g globalFlags) valueFlags() []globalFlag {
+	var flags []globalFlag
+	for _, f := range []globalFlag{g.profile, g.config, g.property} {
+		if f.long != "" {
+			flags = append(flags, f)
+		}
+	}
+	for _, opt := range g.options {
+		flags = append(flags, opt.globalFlag)
+	}
+	return flags
+}
+
+// validate checks that the global options are named and do not clash with each other, the
+// enabled built-in global flags or --help and --verbose.
+func (g globalFlags) validate(versioned bool) error {
+	longs := map[string]string{"help": "--help", "verbose": "--verbose"}
+	shorts := map[string]string{"h": "--help"}
+	builtin := []globalFlag{g.profile, g.config, g.property}
+	if versioned {
+		builtin = append(builtin, g.version)
+	}
+	for _, f := range builtin {
+		if f.long != "" {
+			longs[f.long] = "--" + f.long
+			if f.short != "" {
+				shorts[f.short] = "--" + f.long
+			}
+		}
+	}
+	var problems []string
+	for _, opt := range g.options {
+		switch {
+		case opt.long == "":
+			problems = append(problems, "global option has no name")
+			continue
+		case opt.property == "":
+			problems = append(problems, fmt.Sprintf("global option --%s has no property", opt.long))
+		case len(opt.short) > 1:
+			problems = append(problems, fmt.Sprintf("global option --%s: short flag must be a single character, got %q", opt.long, opt.short))
+		}
+		if other, ok := longs[opt.long]; ok {
+			problems = append(problems, fmt.Sprintf("global option --%s is already declared by %s", opt.long, other))
+		}
+		longs[opt.long] = "--" + opt.long
+		if opt.short != "" {
+			if other, ok := shorts[opt.short]; ok {
+				problems = append(problems, fmt.Sprintf("global option --%s: short flag -%s is already declared by %s", opt.long, opt.short, other))
+			}
+			shorts[opt.short] = "--" + opt.long
+		}
+	}
+	if len(problems) > 0 {
+		return xerrors.Errorf("invalid global options: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// optionProperties returns the properties set by the global options given in args.
+func (g globalFlags) optionProperties(args []string) map[string]string {
+	props := make(map[string]string)
+	for _, opt := range g.options {
+		if value, ok := parseGlobalOption(args, opt.globalFlag); ok {
+			props[opt.property] = value
+		}
+	}
+	return props
+}
+
+// optionDefaults returns the default values of the global options by property.
+func (g globalFlags) optionDefaults() map[string]string {
+	props := make(map[string]string)
+	for _, opt := range g.options {
+		if opt.defaultValue != "" {
+			props[opt.property] = opt.defaultValue
+		}
+	}
+	return props
+}
+
+// split separates the global flags preceding the command path from the rest of args. Global flags
+// are only recognised there, so commands may declare options such as -p or -c themselves; a "--"
+// ends the global flags explicitly and is dropped. A --verbose among them is kept out of the rest.
+func (g globalFlags) split(args []string) (global []string, rest []string) {
+	i := 0
+	for i < len(args) {
+		if args[i] == "--" {
+			return args[:i], args[i+1:]
+		}
+		if args[i] == "--verbose" {
+			i++
+			continue
+		}
+		skip := 0
+		for _, f := range g.valueFlags() {
+			if _, skip = f.match(args[i:]); skip > 0 {
+				break
+			}
+		}
+		if skip == 0 {
+			break
+		}
+		i += skip
+	}
+	if i > len(args) {
+		i = len(args)
+	}
+	return args[:i], args[i:]
+}
+
+// isValueFlag reports whether arg is a global flag whose value follows as a separate word.
+func (g globalFlags) isValueFlag(arg string) bool {
+	for _, f := range g.valueFlags() {
+		if f.is(arg) {
+			return true
+		}
+	}
+	return false
+}
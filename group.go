@@ -5,10 +5,18 @@
 
 package cligo
 
-import "golang.org/x/xerrors"
+import (
+	"reflect"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/xerrors"
+)
 
 // RegisterGroup registers a command group
 func (t *implCliApplication) RegisterGroup(group CliGroup) error {
+	if err := validateExpandedCliTags(group, t.allProperties); err != nil {
+		return err
+	}
 	info := extractParentInfo(group)
 	if info.group == "" {
 		return xerrors.Errorf("parent group not found in cli group: %v", group)
@@ -45,3 +53,70 @@ func (t *implCliApplication) findGroup(parentGroup, name string) CliGroup {
 	}
 	return nil
 }
+
+// findGroupByName returns the registered group with the given name, which is unique across the tree.
+func (t *implCliApplication) findGroupByName(name string) CliGroup {
+	for _, groups := range t.groups {
+		for _, group := range groups {
+			if group.Group() == name {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// groupChain returns the named group and the groups above it, outermost first.
+func (t *implCliApplication) groupChain(name string) []CliGroup {
+	var chain []CliGroup
+	for name != RootGroup {
+		group := t.findGroupByName(name)
+		if group == nil {
+			break
+		}
+		chain = append([]CliGroup{group}, chain...)
+		name = extractParentInfo(group).group
+	}
+	return chain
+}
+
+// addGroupOptions registers the options declared on groups with flagSet and returns them for binding.
+// Options of a group are persistent: they are accepted after the group name as well as after the name of
+// any group or command below it, and bound to the fields of the group bean, which commands may inject.
+func (t *implCliApplication) addGroupOptions(flagSet *pflag.FlagSet, groups []CliGroup) (map[string]optInfo, error) {
+	options := make(map[string]optInfo)
+	for _, group := range groups {
+		groupValue := reflect.ValueOf(group)
+		if groupValue.Kind() != reflect.Ptr || groupValue.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		groupValue = groupValue.Elem()
+		groupFlags := pflag.NewFlagSet(group.Group(), pflag.ContinueOnError)
+		_, groupOptions := t.identifyArgumentsAndOptions(groupValue.Type(), groupValue, groupFlags)
+
+		var err error
+		groupFlags.VisitAll(func(f *pflag.Flag) {
+			switch {
+			case err != nil:
+				return
+			case flagSet.Lookup(f.Name) != nil:
+				err = xerrors.Errorf("option --%s of group '%s' is declared again below it", f.Name, group.Group())
+				return
+			case f.Shorthand != "" && flagSet.ShorthandLookup(f.Shorthand) != nil:
+				err = xerrors.Errorf("short flag -%s of group '%s' is declared again below it", f.Shorthand, group.Group())
+				return
+			}
+			if negated, ok := f.Value.(*negatedValue); ok {
+				negated.flagSet = flagSet
+			}
+			flagSet.AddFlag(f)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for name, opt := range groupOptions {
+			options[name] = opt
+		}
+	}
+	return options, nil
+}
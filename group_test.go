@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// ─── group options ───────────────────────────────────────────────────────────
+
+func TestGroupOptions_BeforeAndAfterCommand(t *testing.T) {
+	t.Setenv("CLOUD_REGION", "")
+	for _, args := range [][]string{
+		{"cloud", "--region=eu-west-1", "nodes", "--zone", "b", "scale", "3"},
+		{"cloud", "nodes", "scale", "3", "-r", "eu-west-1", "--zone=b"},
+		{"cloud", "-r", "eu-west-1", "--", "nodes", "scale", "--zone", "b", "3"},
+	} {
+		cloud, nodes, cmd := &cloudGroup{}, &cloudNodesGroup{}, &scaleNodesCmd{}
+		if err := Run(Args(args), Beans(cloud, nodes, cmd)); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if !cmd.ran || cmd.Count != 3 {
+			t.Errorf("%v: expected scale to run with count 3, got %+v", args, cmd)
+		}
+		if cloud.Region != "eu-west-1" || nodes.Zone != "b" {
+			t.Errorf("%v: expected group options bound, got region=%q zone=%q", args, cloud.Region, nodes.Zone)
+		}
+	}
+}
+
+func TestGroupOptions_DefaultAndEnv(t *testing.T) {
+	t.Setenv("CLOUD_REGION", "")
+	cloud := &cloudGroup{}
+	if err := Run(Args([]string{"cloud", "nodes", "scale", "1"}), Beans(cloud, &cloudNodesGroup{}, &scaleNodesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cloud.Region != "us-east-1" {
+		t.Errorf("expected default region, got %q", cloud.Region)
+	}
+
+	t.Setenv("CLOUD_REGION", "ap-south-1")
+	cloud = &cloudGroup{}
+	if err := Run(Args([]string{"cloud", "nodes", "scale", "1"}), Beans(cloud, &cloudNodesGroup{}, &scaleNodesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cloud.Region != "ap-south-1" {
+		t.Errorf("expected region from $CLOUD_REGION, got %q", cloud.Region)
+	}
+}
+
+func TestGroupOptions_UnknownOptionBeforeCommand(t *testing.T) {
+	err := Run(Args([]string{"cloud", "--bogus", "nodes", "scale", "1"}), Stdout(&bytes.Buffer{}), Stderr(&bytes.Buffer{}),
+		Beans(&cloudGroup{}, &cloudNodesGroup{}, &scaleNodesCmd{}))
+	if ExitCode(err) != ExitCodeUsage {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+}
+
+func TestGroupOptions_ConflictingCommandOption(t *testing.T) {
+	err := Run(Args([]string{"cloud", "region"}), Stdout(&bytes.Buffer{}), Beans(&cloudGroup{}, &regionCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "option --region of group 'cloud' is declared again below it") {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestGroupOptions_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"cloud", "nodes", "scale", "--help"}), Stdout(&out), Beans(&cloudGroup{}, &cloudNodesGroup{}, &scaleNodesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	help := out.String()
+	want := "Options:\n  --port  Node port [default: 22]\n\nGlobal options:\n" +
+		"  --region  Cloud region [default: us-east-1] [$CLOUD_REGION]\n  --zone  Availability zone\n"
+	if !strings.Contains(help, want) {
+		t.Errorf("expected %q in help, got:\n%s", want, help)
+	}
+
+	out.Reset()
+	if err := Run(Args([]string{"cloud", "nodes", "--help"}), Stdout(&out), Beans(&cloudGroup{}, &cloudNodesGroup{}, &scaleNodesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Options:\n  --region  Cloud region") || !strings.Contains(out.String(), "Commands:") {
+		t.Errorf("expected group options in group help, got:\n%s", out.String())
+	}
+}
+
+func TestValidateCliTags_GroupArgument(t *testing.T) {
+	err := validateCliTags(&argGroup{})
+	if err == nil || !strings.Contains(err.Error(), "argGroup.Name: arguments are not supported on groups") {
+		t.Errorf("expected group argument error, got: %v", err)
+	}
+}
+
+// ─── global flags ────────────────────────────────────────────────────────────
+
+func TestGlobalFlags_OnlyBeforeCommandPath(t *testing.T) {
+	cmd := &scaleNodesCmd{}
+	app := New(Args([]string{"-p", "dev", "cloud", "nodes", "scale", "-p", "8080", "2"}))
+	if got := app.(*implCliApplication).getProfiles(); len(got) != 1 || got[0] != "dev" {
+		t.Errorf("expected only the leading profile, got %v", got)
+	}
+	if err := Run(Args([]string{"-p", "dev", "cloud", "nodes", "scale", "-p", "8080", "2"}), Beans(&cloudGroup{}, &cloudNodesGroup{}, cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != 8080 || cmd.Count != 2 {
+		t.Errorf("expected -p bound to the command option, got %+v", cmd)
+	}
+}
+
+func TestGlobalFlags_WithoutShortProfile(t *testing.T) {
+	app := New(Args([]string{"--profile=dev", "-p", "x"}), GlobalFlags(WithoutShortProfile())).(*implCliApplication)
+	if got := app.getProfiles(); len(got) != 1 || got[0] != "dev" {
+		t.Errorf("expected -p not to be a profile flag, got %v", got)
+	}
+
+	var out bytes.Buffer
+	if err := Run(Args([]string{}), Stdout(&out), GlobalFlags(WithoutShortProfile())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "  --profile  Activate glue profiles") {
+		t.Errorf("expected profile without short flag in help, got:\n%s", out.String())
+	}
+}
+
+func TestGlobalFlags_Renamed(t *testing.T) {
+	app := New(Args([]string{"--env", "prod", "-P", "a=1", "--config=x.yaml"}),
+		GlobalFlags(WithProfileFlag("env", "e"), WithPropertyFlag("prop", "P"), WithConfigFlag("", ""))).(*implCliApplication)
+	if got := app.getProfiles(); len(got) != 1 || got[0] != "prod" {
+		t.Errorf("expected profile from --env, got %v", got)
+	}
+	if got := app.getCliProperties(); got["a"] != "1" {
+		t.Errorf("expected property from -P, got %v", got)
+	}
+	if got := app.getConfigFiles(); len(got) != 0 {
+		t.Errorf("expected the disabled config flag to be ignored, got %v", got)
+	}
+
+	var out bytes.Buffer
+	if err := Run(Args([]string{"--help"}), Stdout(&out), Version("1.0"),
+		GlobalFlags(WithProfileFlag("env", "e"), WithConfigFlag("", ""), WithVersionFlag("version", "V"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	help := out.String()
+	if !strings.Contains(help, "-e, --env") || !strings.Contains(help, "-V, --version") || strings.Contains(help, "--config") {
+		t.Errorf("expected renamed and disabled flags in help, got:\n%s", help)
+	}
+
+	out.Reset()
+	if err := Run(Args([]string{"-V"}), Stdout(&out), Version("1.0"), GlobalFlags(WithVersionFlag("version", "V"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "1.0") {
+		t.Errorf("expected version output for -V, got %q", out.String())
+	}
+}
+
+func TestGlobalFlags_Split(t *testing.T) {
+	flags := defaultGlobalFlags()
+	global, rest := flags.split([]string{"-p", "dev", "--verbose", "-Da=1", "--config=x", "cmd", "-c", "y"})
+	if strings.Join(global, " ") != "-p dev --verbose -Da=1 --config=x" || strings.Join(rest, " ") != "cmd -c y" {
+		t.Errorf("unexpected split: %v | %v", global, rest)
+	}
+	global, rest = flags.split([]string{"-p", "dev", "--", "-p"})
+	if strings.Join(global, " ") != "-p dev" || strings.Join(rest, " ") != "-p" {
+		t.Errorf("expected -- to end global flags, got: %v | %v", global, rest)
+	}
+}
@@ -16,30 +16,42 @@ func (t *implCliApplication) printHelp(groupName string, stack []string) {
 	path := strings.Join(stack, " ")
 
 	if len(groups)+len(commands) > 0 {
-		Echo("%s: %s %s [OPTIONS] COMMAND [ARGS]...", t.styled("Usage", ansiBold), t.name, path)
+		t.Echo("%s: %s %s [OPTIONS] COMMAND [ARGS]...", t.styled("Usage", ansiBold), t.name, path)
 	} else {
-		Echo("%s: %s %s [OPTIONS] [ARGS]...", t.styled("Usage", ansiBold), t.name, path)
+		t.Echo("%s: %s %s [OPTIONS] [ARGS]...", t.styled("Usage", ansiBold), t.name, path)
 	}
 
 	help := t.helps[groupName]
 	if help != "" {
-		Echo("\n%s\n", help)
+		t.Echo("\n%s\n", help)
 	}
 
 	if groupName == RootGroup {
-		Echo("%s:", t.styled("Options", ansiBold))
-		if t.version != "" {
-			Echo("  %s  Show the version and exit.", t.styled("-v, --version", ansiYellow))
+		t.Echo("%s:", t.styled("Options", ansiBold))
+		flags := t.globalFlags
+		if t.version != "" && flags.version.long != "" {
+			t.Echo("  %s  Show the version and exit.", t.styled(flags.version.display(), ansiYellow))
 		}
-		Echo("  %s  Activate glue profiles (comma-separated).", t.styled("-p, --profile", ansiYellow))
-		Echo("  %s   Load config file (repeatable).", t.styled("-c, --config", ansiYellow))
-		Echo("  %s  Override a property (key=value, repeatable).", t.styled("-D, --property", ansiYellow))
-		Echo("  %s      Show extended logging information.", t.styled("--verbose", ansiYellow))
-		Echo("  %s   Show this message and exit.", t.styled("-h, --help", ansiYellow))
-		Echo("")
+		if flags.profile.long != "" {
+			t.Echo("  %s  Activate glue profiles (comma-separated).", t.styled(flags.profile.display(), ansiYellow))
+		}
+		if flags.config.long != "" {
+			t.Echo("  %s   Load config file (repeatable).", t.styled(flags.config.display(), ansiYellow))
+		}
+		if flags.property.long != "" {
+			t.Echo("  %s  Override a property (key=value, repeatable).", t.styled(flags.property.display(), ansiYellow))
+		}
+		for _, opt := range flags.options {
+			t.Echo("  %s  %s", t.styled(opt.display(), ansiYellow), opt.helpText())
+		}
+		t.Echo("  %s      Show extended logging information.", t.styled("--verbose", ansiYellow))
+		t.Echo("  %s   Show this message and exit.", t.styled("-h, --help", ansiYellow))
+		t.Echo("")
+	} else if t.printGroupOptionDetails("Options", t.groupChain(groupName)) {
+		t.Echo("")
 	}
 
-	Echo("%s:", t.styled("Commands", ansiBold))
+	t.Echo("%s:", t.styled("Commands", ansiBold))
 	for _, grp := range groups {
 		if t.hidden[grp] {
 			continue
@@ -49,7 +61,7 @@ func (t *implCliApplication) printHelp(groupName string, stack []string) {
 		if alias, ok := t.aliasOf[grp]; ok {
 			name = name + " (" + alias + ")"
 		}
-		Echo("  %s\t%s", name, shortDesc)
+		t.Echo("  %s\t%s", name, shortDesc)
 	}
 
 	for _, cmd := range commands {
@@ -61,7 +73,7 @@ func (t *implCliApplication) printHelp(groupName string, stack []string) {
 		if alias, ok := t.aliasOf[cmd]; ok {
 			name = name + " (" + alias + ")"
 		}
-		Echo("  %s\t%s", name, shortDesc)
+		t.Echo("  %s\t%s", name, shortDesc)
 	}
 
 }
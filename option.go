@@ -7,6 +7,7 @@ package cligo
 
 import (
 	"context"
+	"io"
 
 	"go.arpabet.com/glue"
 )
@@ -98,9 +99,10 @@ func Context(ctx context.Context) Option {
 }
 
 // ConfigFile specifies a config file path to try loading into glue.Properties.
-// Call multiple times to specify fallback paths — the first existing file is loaded.
+// Call multiple times to specify fallback paths — the first existing file is loaded,
+// or every existing file with MergeConfigFiles.
 // Supported formats (by extension): .properties, .yaml, .yml, .json, .toml.
-// These are merged with any --config CLI flag values.
+// These are merged with any --config CLI flag values, which are tried first.
 // Priority: flags > env vars > config file > defaults.
 func ConfigFile(path string) Option {
 	return optionFunc(func(a *implCliApplication) {
@@ -108,6 +110,35 @@ func ConfigFile(path string) Option {
 	})
 }
 
+// MergeConfigFiles loads every existing config file instead of only the first, in declared order,
+// so a system file can be overlaid by a user file and a project file; later files override earlier
+// ones. Files given with --config are loaded last and override all ConfigFile paths:
+//
+//	cligo.Main(
+//		cligo.MergeConfigFiles(),
+//		cligo.ConfigFile("/etc/app/config.yaml"),
+//		cligo.ConfigFile(os.ExpandEnv("$HOME/.config/app/config.yaml")),
+//		cligo.ConfigFile("app.yaml"),
+//	)
+func MergeConfigFiles() Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.mergeConfig = true
+	})
+}
+
+// DiscoverConfig searches the usual locations for the config file of the application, named after
+// Name: $XDG_CONFIG_HOME/<name>/config.{yaml,toml,json,properties} (~/.config when unset),
+// /etc/<name>/config.* and <name>.* in the current directory and the directories above it up to
+// the repository root. Without MergeConfigFiles the most specific file found is loaded, after the
+// ConfigFile paths; with it, every file found is loaded below the ConfigFile paths, /etc first.
+// The <NAME>_CONFIG environment variable, e.g. MY_APP_CONFIG, names the file to load instead;
+// it is an error when that file does not exist.
+func DiscoverConfig() Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.discovery = true
+	})
+}
+
 // Profile sets active glue profiles programmatically.
 // These are merged with any --profile CLI flag values.
 func Profile(profile string) Option {
@@ -124,3 +155,122 @@ func Color(enabled bool) Option {
 		a.color = &enabled
 	})
 }
+
+// Args sets the command-line arguments to parse, without the program name.
+// Defaults to os.Args[1:].
+func Args(args []string) Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.args = args
+	})
+}
+
+// Stdout sets the writer for regular output, help and usage messages. Defaults to os.Stdout.
+func Stdout(w io.Writer) Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.stdout = w
+	})
+}
+
+// Stderr sets the writer for error output. Defaults to os.Stderr.
+func Stderr(w io.Writer) Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.stderr = w
+	})
+}
+
+// Stdin sets the reader commands obtain through CliApplication.Stdin. Defaults to os.Stdin.
+func Stdin(r io.Reader) Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.stdin = r
+	})
+}
+
+// GlobalOption declares an application-wide flag such as --log-level, accepted before the command
+// path like the built-in global flags and listed with them in the root help. Its value is set as
+// the given property with the priority of a -D/--property override, so it wins over environment
+// variables and config files; the default value, if not empty, only applies when no other source
+// sets the property. The short name may be empty.
+//
+//	cligo.GlobalOption("log-level", "l", "log.level", "Log level", "info")
+func GlobalOption(name, short, property, help, defaultValue string) Option {
+	return optionFunc(func(a *implCliApplication) {
+		a.globalFlags.options = append(a.globalFlags.options, globalOption{
+			globalFlag:   globalFlag{long: name, short: short},
+			property:     property,
+			help:         help,
+			defaultValue: defaultValue,
+		})
+	})
+}
+
+// GlobalFlagOption renames or disables one of the built-in global flags, see GlobalFlags.
+type GlobalFlagOption func(*globalFlags)
+
+// GlobalFlags changes the names of the built-in global flags (--version, --profile, --config and
+// --property), so an application can reclaim them for its own options. Global flags are only
+// recognised before the command path in any case:
+//
+//	cligo.GlobalFlags(cligo.WithoutShortProfile(), cligo.WithConfigFlag("settings", "s"))
+func GlobalFlags(options ...GlobalFlagOption) Option {
+	return optionFunc(func(a *implCliApplication) {
+		for _, opt := range options {
+			opt(&a.globalFlags)
+		}
+	})
+}
+
+// WithVersionFlag renames the --version/-v flag. An empty long name disables it, an empty short name drops -v.
+func WithVersionFlag(long, short string) GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.version = globalFlag{long: long, short: short}
+	}
+}
+
+// WithProfileFlag renames the --profile/-p flag. An empty long name disables it, an empty short name drops -p.
+func WithProfileFlag(long, short string) GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.profile = globalFlag{long: long, short: short}
+	}
+}
+
+// WithConfigFlag renames the --config/-c flag. An empty long name disables it, an empty short name drops -c.
+func WithConfigFlag(long, short string) GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.config = globalFlag{long: long, short: short}
+	}
+}
+
+// WithPropertyFlag renames the --property/-D flag. An empty long name disables it, an empty short name drops -D.
+func WithPropertyFlag(long, short string) GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.property = globalFlag{long: long, short: short}
+	}
+}
+
+// WithoutShortVersion drops the -v short form of the version flag.
+func WithoutShortVersion() GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.version.short = ""
+	}
+}
+
+// WithoutShortProfile drops the -p short form of the profile flag.
+func WithoutShortProfile() GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.profile.short = ""
+	}
+}
+
+// WithoutShortConfig drops the -c short form of the config flag.
+func WithoutShortConfig() GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.config.short = ""
+	}
+}
+
+// WithoutShortProperty drops the -D short form of the property flag.
+func WithoutShortProperty() GlobalFlagOption {
+	return func(g *globalFlags) {
+		g.property.short = ""
+	}
+}
@@ -5,29 +5,27 @@
 
 package cligo
 
-import "strings"
+import (
+	"strings"
 
-// parseGlobalFlag extracts all values for a given --flag/-short from args.
-// Supports --flag value, --flag=value, -s value, -s=value, and repeated usage.
-func parseGlobalFlag(args []string, flag string, short string) []string {
-	longPrefix := "--" + flag
-	shortPrefix := "-" + short
+	"golang.org/x/xerrors"
+)
+
+// parseGlobalFlag extracts all values of the global flag from args.
+// Supports --flag value, --flag=value, -s value, -s=value, -svalue, comma-separated values and repeated usage.
+func parseGlobalFlag(args []string, flag globalFlag) []string {
 	var values []string
-	for i, arg := range args {
-		var value string
-		if (arg == longPrefix || arg == shortPrefix) && i+1 < len(args) {
-			value = args[i+1]
-		} else if strings.HasPrefix(arg, longPrefix+"=") {
-			value = strings.TrimPrefix(arg, longPrefix+"=")
-		} else if strings.HasPrefix(arg, shortPrefix+"=") {
-			value = strings.TrimPrefix(arg, shortPrefix+"=")
+	for i := 0; i < len(args); {
+		value, skip := flag.match(args[i:])
+		if skip == 0 {
+			i++
+			continue
 		}
-		if value != "" {
-			for _, v := range strings.Split(value, ",") {
-				v = strings.TrimSpace(v)
-				if v != "" {
-					values = append(values, v)
-				}
+		i += skip
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				values = append(values, v)
 			}
 		}
 	}
@@ -36,26 +34,17 @@ func parseGlobalFlag(args []string, flag string, short string) []string {
 
 // parseGlobalProperties extracts -D/--property key=value overrides from args.
 // Accepted forms (all repeatable): -Dkey=value, -D key=value, --property key=value
-// and --property=key=value. The key is split from the value on the first '='; later
-// occurrences of a key win.
-func parseGlobalProperties(args []string) map[string]string {
+// and --property=key=value, with the names of the given flag. The key is split from
+// the value on the first '='; later occurrences of a key win.
+func parseGlobalProperties(args []string, flag globalFlag) map[string]string {
 	props := make(map[string]string)
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		var kv string
-		switch {
-		case arg == "-D" || arg == "--property":
-			if i+1 < len(args) {
-				i++
-				kv = args[i]
-			}
-		case strings.HasPrefix(arg, "-D"):
-			kv = arg[len("-D"):]
-		case strings.HasPrefix(arg, "--property="):
-			kv = arg[len("--property="):]
-		default:
+	for i := 0; i < len(args); {
+		kv, skip := flag.match(args[i:])
+		if skip == 0 {
+			i++
 			continue
 		}
+		i += skip
 		if key, value, ok := strings.Cut(kv, "="); ok && key != "" {
 			props[key] = value
 		}
@@ -63,38 +52,101 @@ func parseGlobalProperties(args []string) map[string]string {
 	return props
 }
 
-// globalPropertyArgSkip reports whether args[0] begins a -D/--property override and
-// how many tokens it consumes: 1 when the value is attached (-Dkey=v, --property=k=v)
-// and 2 when it follows as a separate token (-D k=v, --property k=v). It lets the
-// command parser step over global property flags wherever they appear.
-func globalPropertyArgSkip(args []string) (matched bool, skip int) {
-	switch arg := args[0]; {
-	case arg == "-D" || arg == "--property":
-		return true, 2
-	case strings.HasPrefix(arg, "-D") || strings.HasPrefix(arg, "--property="):
-		return true, 1
-	default:
-		return false, 0
+// parseGlobalOption returns the value of the last occurrence of a global option in args.
+// Supports --flag value, --flag=value, -s value, -s=value and -svalue.
+func parseGlobalOption(args []string, flag globalFlag) (string, bool) {
+	var value string
+	found := false
+	for i := 0; i < len(args); {
+		v, skip := flag.match(args[i:])
+		if skip == 0 {
+			i++
+			continue
+		}
+		i += skip
+		value, found = v, true
 	}
+	return value, found
 }
 
-// parseCliTag parses a cli tag string into a map of key-value pairs
+// parseCliTag parses a cli tag string into a map of key-value pairs.
+// Malformed tags are rejected at registration by validateCliTags, so errors are not reported here.
 func parseCliTag(tag string) map[string]string {
+	result, _ := splitCliTag(tag)
+	return result
+}
+
+// splitCliTag parses a cli tag string into a map of key-value pairs.
+// Pairs are separated by commas. A key without '=' maps to "true". A value may be
+// enclosed in single quotes to contain commas (help='Speed, in knots'), and a
+// backslash escapes a comma, a single quote or a backslash (help=Speed\, in knots,
+// written as `cli:"help=Speed\\, in knots"` in a struct tag); any other backslash is kept as is.
+func splitCliTag(tag string) (map[string]string, error) {
 	result := make(map[string]string)
 	if tag == "" {
-		return result
+		return result, nil
 	}
-	parts := strings.Split(tag, ",")
 
-	for _, part := range parts {
-		kv := strings.SplitN(part, "=", 2)
+	var part strings.Builder
+	var parts []string
+	quoted := false     // inside a single-quoted value
+	closed := false     // a quoted value was just closed, only ',' may follow
+	inValue := false    // the '=' separating key and value was seen
+	valueStart := false // the next byte starts a value
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		switch {
+		case c == '\\' && i+1 < len(tag) && (tag[i+1] == ',' || tag[i+1] == '\'' || tag[i+1] == '\\'):
+			if closed {
+				return result, xerrors.Errorf("unexpected %q after quoted value in %q", tag[i:i+2], tag)
+			}
+			i++
+			part.WriteByte(tag[i])
+			valueStart = false
+		case c == '\\' && i+1 == len(tag):
+			return result, xerrors.Errorf("trailing backslash in %q", tag)
+		case c == '\'' && quoted:
+			quoted = false
+			closed = true
+		case c == '\'' && valueStart:
+			quoted = true
+			valueStart = false
+		case c == ',' && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+			closed = false
+			inValue = false
+			valueStart = false
+		default:
+			if closed {
+				return result, xerrors.Errorf("unexpected %q after quoted value in %q", string(c), tag)
+			}
+			part.WriteByte(c)
+			valueStart = c == '=' && !inValue
+			inValue = inValue || c == '='
+		}
+	}
+	if quoted {
+		return result, xerrors.Errorf("unterminated quote in %q", tag)
+	}
+	parts = append(parts, part.String())
+
+	for _, p := range parts {
+		kv := strings.SplitN(p, "=", 2)
+		key := kv[0]
+		if key == "" {
+			return result, xerrors.Errorf("empty key in %q", tag)
+		}
+		if strings.ContainsAny(key, " \t'") {
+			return result, xerrors.Errorf("invalid key %q in %q, quote values containing commas, e.g. help='a, b'", key, tag)
+		}
 		if len(kv) == 2 {
-			result[kv[0]] = kv[1]
-		} else if len(kv) == 1 {
+			result[key] = kv[1]
+		} else {
 			// Handle boolean flags or other special cases
-			result[kv[0]] = "true"
+			result[key] = "true"
 		}
 	}
 
-	return result
+	return result, nil
 }
@@ -56,3 +56,66 @@ func TestParseCliTag_GroupRef(t *testing.T) {
 		t.Errorf("expected group=ship, got %v", result)
 	}
 }
+
+func TestParseCliTag_QuotedValueWithComma(t *testing.T) {
+	result, err := splitCliTag("option=speed,help='Speed, in knots',default=10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["help"] != "Speed, in knots" {
+		t.Errorf("help: expected 'Speed, in knots', got %q", result["help"])
+	}
+	if result["default"] != "10" || len(result) != 3 {
+		t.Errorf("expected 3 keys with default=10, got %v", result)
+	}
+}
+
+func TestParseCliTag_EscapedComma(t *testing.T) {
+	result, err := splitCliTag(`option=tags,default=a\,b,help=It\'s a \\ list`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["default"] != "a,b" {
+		t.Errorf("default: expected 'a,b', got %q", result["default"])
+	}
+	if result["help"] != `It's a \ list` {
+		t.Errorf(`help: expected "It's a \ list", got %q`, result["help"])
+	}
+}
+
+func TestParseCliTag_EscapedQuoteInsideQuotes(t *testing.T) {
+	result, err := splitCliTag(`help='Don\'t, ever'`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["help"] != "Don't, ever" {
+		t.Errorf("help: expected \"Don't, ever\", got %q", result["help"])
+	}
+}
+
+func TestParseCliTag_ApostropheInsideValueKept(t *testing.T) {
+	result, err := splitCliTag(`help=Don't move,pattern=^\d+$`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["help"] != "Don't move" {
+		t.Errorf("help: expected \"Don't move\", got %q", result["help"])
+	}
+	if result["pattern"] != `^\d+$` {
+		t.Errorf(`pattern: expected "^\d+$", got %q`, result["pattern"])
+	}
+}
+
+func TestParseCliTag_Errors(t *testing.T) {
+	for _, tag := range []string{
+		"option=speed,help=Speed, in knots",
+		"help='unterminated",
+		"help='a'b",
+		"option=speed,,default=1",
+		`help=trailing\`,
+	} {
+		if _, err := splitCliTag(tag); err == nil {
+			t.Errorf("expected error for tag %q", tag)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package cligo
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -18,70 +19,126 @@ func (t *implCliApplication) printCommandHelp(cmd CliCommand, stack []string) {
 	cmdValue := reflect.ValueOf(cmd).Elem()
 	cmdType := cmdValue.Type()
 
-	Echo(t.getCommandUsage(cmd, stack))
+	t.Echo(t.getCommandUsage(cmd, stack))
 
 	shortDesc, longDesc := cmd.Help()
 	if len(longDesc) == 0 {
 		longDesc = shortDesc
 	}
 
-	Echo("%s\n", longDesc)
+	t.Echo("%s\n", longDesc)
 
 	// Print argument details
 	t.printArgumentDetails(cmdType)
 
-	// Finally print option details
-	t.printOptionDetails(cmdType)
+	// Finally print option details, followed by the options inherited from the groups above the command
+	groups := t.groupChain(extractParentInfo(cmd).group)
+	if t.printOptionDetails(cmdType) && t.hasGroupOptions(groups) {
+		fmt.Fprintln(t.stdout)
+	}
+	t.printGroupOptionDetails("Global options", groups)
 }
 
 func (t *implCliApplication) printArgumentDetails(cmdType reflect.Type) {
 	var argLines []string
-	for i := 0; i < cmdType.NumField(); i++ {
-		field := cmdType.Field(i)
-		cliTag := field.Tag.Get("cli")
-		if cliTag == "" {
-			continue
-		}
-
-		tagParts := parseCliTag(cliTag)
-		if argName, ok := tagParts["argument"]; ok {
+	for _, field := range t.expandedCliFields(cmdType) {
+		tagParts := field.tagParts
+		if argName, ok := tagParts["argument"]; ok && !field.embedded() {
 			help := tagParts["help"]
 			if help == "" {
 				help = fmt.Sprintf("%s argument", argName)
 			}
 			_, hasDefault := tagParts["default"]
 			_, hasRequired := tagParts["required"]
-			if hasDefault && !hasRequired {
+			if choices, ok := tagParts["choices"]; ok {
+				help = help + " [" + choices + "]"
+			}
+			if isVariadicType(field.Type) {
+				help = help + variadicArityText(tagParts)
+			} else if hasDefault && !hasRequired {
 				help = help + fmt.Sprintf(" [default: %s]", tagParts["default"])
 			} else {
 				help = help + " [required]"
 			}
-			argLines = append(argLines, fmt.Sprintf("  %s\t%s", t.styled(strings.ToUpper(argName), ansiGreen), help))
+			name := t.styled(strings.ToUpper(argName), ansiGreen)
+			if isCustomType(field.Type) {
+				name = name + " " + customTypeName(field.Type)
+			}
+			argLines = append(argLines, fmt.Sprintf("  %s\t%s", name, help))
 		}
 	}
 	if len(argLines) > 0 {
-		Echo("%s:", t.styled("Arguments", ansiBold))
+		t.Echo("%s:", t.styled("Arguments", ansiBold))
 		for _, line := range argLines {
-			fmt.Println(line)
+			fmt.Fprintln(t.stdout, line)
+		}
+		fmt.Fprintln(t.stdout)
+	}
+}
+
+// printOptionDetails prints the command's own options under "Options", followed by the options of
+// every embedded struct under its own heading. It reports whether there were any options to print.
+func (t *implCliApplication) printOptionDetails(cmdType reflect.Type) bool {
+	headings, lines := t.optionLines(cmdType)
+	sort.SliceStable(headings, func(i, j int) bool { return headings[i] == "" && headings[j] != "" })
+	for i, heading := range headings {
+		if i > 0 {
+			fmt.Fprintln(t.stdout)
+		}
+		title := heading
+		if title == "" {
+			title = "Options"
 		}
-		fmt.Println()
+		t.Echo("%s:", t.styled(title, ansiBold))
+		for _, line := range lines[heading] {
+			fmt.Fprintln(t.stdout, line)
+		}
+	}
+	return len(headings) > 0
+}
+
+// printGroupOptionDetails prints the options of groups, outermost first, under a single title.
+// It reports whether there were any options to print.
+func (t *implCliApplication) printGroupOptionDetails(title string, groups []CliGroup) bool {
+	lines := t.groupOptionLines(groups)
+	if len(lines) == 0 {
+		return false
 	}
+	t.Echo("%s:", t.styled(title, ansiBold))
+	for _, line := range lines {
+		fmt.Fprintln(t.stdout, line)
+	}
+	return true
+}
+
+// hasGroupOptions reports whether any of groups declares options.
+func (t *implCliApplication) hasGroupOptions(groups []CliGroup) bool {
+	return len(t.groupOptionLines(groups)) > 0
 }
 
-func (t *implCliApplication) printOptionDetails(cmdType reflect.Type) {
-	var hasOptions bool
-	for i := 0; i < cmdType.NumField(); i++ {
-		field := cmdType.Field(i)
-		cliTag := field.Tag.Get("cli")
-		if cliTag == "" {
-			continue
+// groupOptionLines renders the help lines of the options declared on groups, outermost first.
+func (t *implCliApplication) groupOptionLines(groups []CliGroup) []string {
+	var all []string
+	for _, group := range groups {
+		headings, lines := t.optionLines(reflect.TypeOf(group))
+		for _, heading := range headings {
+			all = append(all, lines[heading]...)
 		}
+	}
+	return all
+}
 
-		tagParts := parseCliTag(cliTag)
+// optionLines renders the help lines of the options declared on cmdType, keyed by the heading of the
+// embedded struct declaring them, "" for its own fields. Headings are listed in declaration order.
+func (t *implCliApplication) optionLines(cmdType reflect.Type) ([]string, map[string][]string) {
+	var headings []string
+	lines := make(map[string][]string)
+	relations := optionRelationTexts(cmdType)
+	for _, field := range t.expandedCliFields(cmdType) {
+		tagParts := field.tagParts
 		if optName, ok := tagParts["option"]; ok {
-			if !hasOptions {
-				Echo("%s:", t.styled("Options", ansiBold))
-				hasOptions = true
+			if _, ok := lines[field.heading]; !ok {
+				headings = append(headings, field.heading)
 			}
 
 			defaultVal := tagParts["default"]
@@ -90,17 +147,60 @@ func (t *implCliApplication) printOptionDetails(cmdType reflect.Type) {
 				help = fmt.Sprintf("%s option", optName)
 			}
 
+			if choices, ok := tagParts["choices"]; ok {
+				help = help + " [" + choices + "]"
+			}
+			help = help + relations[optName]
+
 			defaultText := ""
 			if defaultVal != "" {
 				defaultText = fmt.Sprintf(" [default: %s]", defaultVal)
 			}
 
+			if _, ok := tagParts["required"]; ok {
+				defaultText = " [required]"
+			}
+
 			envText := ""
 			if envVar, ok := tagParts["env"]; ok {
 				envText = fmt.Sprintf(" [$%s]", envVar)
 			}
+			if property, ok := tagParts["property"]; ok {
+				envText += fmt.Sprintf(" [property: %s]", property)
+			}
 
-			fmt.Printf("  %s  %s%s%s\n", t.styled("--"+optName, ansiYellow), help, defaultText, envText)
+			if _, ok := tagParts["count"]; ok {
+				help = help + " [repeatable]"
+			}
+
+			name := t.styled("--"+optName, ansiYellow)
+			if _, ok := tagParts["negatable"]; ok {
+				name = t.styled("--[no-]"+optName, ansiYellow)
+			}
+			if isCustomType(field.Type) {
+				name = name + " " + customTypeName(field.Type)
+			} else if field.Type.Kind() == reflect.Map {
+				name = name + " key=value"
+			}
+
+			lines[field.heading] = append(lines[field.heading], fmt.Sprintf("  %s  %s%s%s", name, help, defaultText, envText))
 		}
 	}
+	return headings, lines
+}
+
+// variadicArityText describes how many values a variadic argument accepts.
+func variadicArityText(tagParts map[string]string) string {
+	min, max, _ := parseArity(tagParts)
+	switch {
+	case max > 0 && min == max:
+		return fmt.Sprintf(" [exactly %d]", min)
+	case max > 0:
+		return fmt.Sprintf(" [%d to %d]", min, max)
+	case min > 1:
+		return fmt.Sprintf(" [at least %d]", min)
+	case min == 1:
+		return " [required]"
+	}
+	return ""
 }
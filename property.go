@@ -42,3 +42,15 @@ func (r *cliPropertyResolver) Keys() []string {
 	}
 	return keys
 }
+
+// cliDefaultResolverPriority places the defaults of global options below glue's
+// file/map resolvers (100), so any configured value wins over them.
+const cliDefaultResolverPriority = 50
+
+// cliDefaultResolver is a glue.PropertyResolver backed by the default values of the
+// global options declared with GlobalOption.
+type cliDefaultResolver struct {
+	cliPropertyResolver
+}
+
+func (r *cliDefaultResolver) Priority() int { return cliDefaultResolverPriority }
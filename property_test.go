@@ -6,8 +6,10 @@
 package cligo
 
 import (
+	"bytes"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -21,7 +23,7 @@ func TestParseGlobalProperties_AllForms(t *testing.T) {
 		"--property=c.d=2", // attached long
 		"run", "positional", // ignored
 	}
-	got := parseGlobalProperties(args)
+	got := parseGlobalProperties(args, defaultGlobalFlags().property)
 	want := map[string]string{
 		"http-server.bind-address": "127.0.0.1:9123",
 		"log.level":                "debug",
@@ -35,26 +37,26 @@ func TestParseGlobalProperties_AllForms(t *testing.T) {
 
 func TestParseGlobalProperties_ValueWithEquals(t *testing.T) {
 	// only the first '=' splits key from value
-	got := parseGlobalProperties([]string{"-Dquery=a=b=c"})
+	got := parseGlobalProperties([]string{"-Dquery=a=b=c"}, defaultGlobalFlags().property)
 	if got["query"] != "a=b=c" {
 		t.Fatalf("value with '=': got %q, want %q", got["query"], "a=b=c")
 	}
 }
 
 func TestParseGlobalProperties_None(t *testing.T) {
-	if got := parseGlobalProperties([]string{"run", "--verbose"}); len(got) != 0 {
+	if got := parseGlobalProperties([]string{"run", "--verbose"}, defaultGlobalFlags().property); len(got) != 0 {
 		t.Fatalf("expected no properties, got %v", got)
 	}
 }
 
 func TestParseGlobalProperties_LastWins(t *testing.T) {
-	got := parseGlobalProperties([]string{"-Dk=1", "-Dk=2"})
+	got := parseGlobalProperties([]string{"-Dk=1", "-Dk=2"}, defaultGlobalFlags().property)
 	if got["k"] != "2" {
 		t.Fatalf("last occurrence should win: got %q, want 2", got["k"])
 	}
 }
 
-// ─── globalPropertyArgSkip ───────────────────────────────────────────────────
+// ─── globalFlag.match ────────────────────────────────────────────────────────
 
 func TestGlobalPropertyArgSkip(t *testing.T) {
 	cases := []struct {
@@ -70,9 +72,9 @@ func TestGlobalPropertyArgSkip(t *testing.T) {
 		{"-c", false, 0},
 	}
 	for _, tc := range cases {
-		matched, skip := globalPropertyArgSkip([]string{tc.arg})
-		if matched != tc.wantMatched || skip != tc.wantSkip {
-			t.Errorf("globalPropertyArgSkip(%q) = (%v,%d), want (%v,%d)", tc.arg, matched, skip, tc.wantMatched, tc.wantSkip)
+		_, skip := defaultGlobalFlags().property.match([]string{tc.arg})
+		if matched := skip > 0; matched != tc.wantMatched || skip != tc.wantSkip {
+			t.Errorf("property.match(%q) skips %d, want (%v,%d)", tc.arg, skip, tc.wantMatched, tc.wantSkip)
 		}
 	}
 }
@@ -98,3 +100,174 @@ func TestCliPropertyResolver(t *testing.T) {
 		t.Fatalf("Keys() = %v, want [x.y]", keys)
 	}
 }
+
+// ─── GlobalOption ────────────────────────────────────────────────────────────
+
+func TestGlobalOption_SetsProperty(t *testing.T) {
+	cmd := &propCmd{}
+	err := Run(Args([]string{"--app-port", "9000", "propcmd"}), Beans(cmd),
+		GlobalOption("app-port", "P", "app.port", "Listen port", "8080"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != "9000" {
+		t.Errorf("expected app.port from --app-port, got %q", cmd.Port)
+	}
+
+	cmd = &propCmd{}
+	err = Run(Args([]string{"-Dapp.port=1", "-P7000", "propcmd"}), Beans(cmd),
+		GlobalOption("app-port", "P", "app.port", "Listen port", "8080"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != "7000" {
+		t.Errorf("expected -P to win over -D, got %q", cmd.Port)
+	}
+}
+
+func TestGlobalOption_Default(t *testing.T) {
+	cmd := &propCmd{}
+	if err := Run(Args([]string{"propcmd"}), Beans(cmd), GlobalOption("app-port", "", "app.port", "", "8080")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != "8080" {
+		t.Errorf("expected default app.port, got %q", cmd.Port)
+	}
+
+	path := writeTempFile(t, "config.properties", "app.port = 443\n")
+	cmd = &propCmd{}
+	if err := Run(Args([]string{"propcmd"}), ConfigFile(path), Beans(cmd), GlobalOption("app-port", "", "app.port", "", "8080")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != "443" {
+		t.Errorf("expected config file to win over the default, got %q", cmd.Port)
+	}
+}
+
+func TestGlobalOption_OnlyBeforeCommandPath(t *testing.T) {
+	app := New(Args([]string{"propcmd", "--app-port", "9000"}), GlobalOption("app-port", "", "app.port", "", "")).(*implCliApplication)
+	if props := app.getCliProperties(); props != nil {
+		t.Errorf("expected no overrides after the command name, got %v", props)
+	}
+}
+
+func TestGlobalOption_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"--help"}), Stdout(&out), GlobalOption("log-level", "l", "log.level", "Log level", "info")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "  -l, --log-level  Log level [default: info] [property: log.level]\n  --verbose"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("expected %q in root help, got:\n%s", want, out.String())
+	}
+}
+
+func TestGlobalOption_Conflicts(t *testing.T) {
+	err := Run(Args([]string{}), Stdout(&bytes.Buffer{}),
+		GlobalOption("config", "", "app.config", "", ""),
+		GlobalOption("level", "p", "log.level", "", ""),
+		GlobalOption("verbose", "", "", "", ""))
+	if err == nil {
+		t.Fatal("expected error for conflicting global options")
+	}
+	for _, want := range []string{
+		"global option --config is already declared by --config",
+		"global option --level: short flag -p is already declared by --profile",
+		"global option --verbose has no property",
+		"global option --verbose is already declared by --verbose",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+// ─── property= tag ───────────────────────────────────────────────────────────
+
+func TestPropertyTag_Precedence(t *testing.T) {
+	t.Setenv("LISTEN_PORT", "")
+	cmd := &listenCmd{}
+	if err := Run(Args([]string{"-Dserver.host=h", "-Dserver.port=8080", "-Dserver.tags=a,b", "listen"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Host != "h" || cmd.Port != 8080 || !reflect.DeepEqual(cmd.Tags, []string{"a", "b"}) {
+		t.Errorf("expected options from properties, got %+v", cmd)
+	}
+
+	t.Setenv("LISTEN_PORT", "9090")
+	cmd = &listenCmd{}
+	if err := Run(Args([]string{"-Dserver.host=h", "-Dserver.port=8080", "listen"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != 9090 {
+		t.Errorf("expected $LISTEN_PORT to win over the property, got %d", cmd.Port)
+	}
+
+	cmd = &listenCmd{}
+	if err := Run(Args([]string{"-Dserver.host=h", "-Dserver.port=8080", "listen", "--port=7070"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != 7070 {
+		t.Errorf("expected the flag to win, got %d", cmd.Port)
+	}
+
+	t.Setenv("LISTEN_PORT", "")
+	cmd = &listenCmd{}
+	if err := Run(Args([]string{"listen", "--host=h"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Port != 80 {
+		t.Errorf("expected the default without property, got %d", cmd.Port)
+	}
+}
+
+func TestPropertyTag_ConfigFile(t *testing.T) {
+	t.Setenv("LISTEN_PORT", "")
+	path := writeTempFile(t, "config.yaml", "server:\n  host: cfg.local\n  port: 6060\n")
+	cmd := &listenCmd{}
+	if err := Run(Args([]string{"listen"}), ConfigFile(path), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Host != "cfg.local" || cmd.Port != 6060 {
+		t.Errorf("expected options from the config file, got %+v", cmd)
+	}
+}
+
+func TestPropertyTag_RequiredAndInvalid(t *testing.T) {
+	t.Setenv("LISTEN_PORT", "")
+	err := Run(Args([]string{"listen"}), Stdout(&bytes.Buffer{}), Beans(&listenCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "missing required option '--host'") {
+		t.Errorf("expected missing --host, got: %v", err)
+	}
+
+	err = Run(Args([]string{"-Dserver.host=h", "-Dserver.port=abc", "listen"}), Stdout(&bytes.Buffer{}), Beans(&listenCmd{}))
+	if ExitCode(err) != ExitCodeUsage || !strings.Contains(err.Error(), "invalid integer for option --port from property server.port: abc") {
+		t.Errorf("expected invalid property value error, got: %v", err)
+	}
+}
+
+func TestPropertyTag_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"listen", "--help"}), Stdout(&out), Beans(&listenCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "  --port  Bind port [default: 80] [$LISTEN_PORT] [property: server.port]\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("expected %q in help, got:\n%s", want, out.String())
+	}
+}
+
+func TestValidateCliTags_Property(t *testing.T) {
+	err := validateCliTags(&badPropertyCmd{})
+	if err == nil {
+		t.Fatal("expected error for invalid property= tags")
+	}
+	for _, want := range []string{
+		"badPropertyCmd.Name: property= applies only to options",
+		"badPropertyCmd.Port: empty property name",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
+	"go.arpabet.com/glue"
 	"golang.org/x/xerrors"
 )
 
@@ -20,80 +22,186 @@ type argInfo struct {
 	position int
 	required bool
 	defVal   string
+	layout   string
+	variadic bool
+	min      int
+	max      int
+	choices  []string
+
+	constraints constraints
 }
 
-// setSliceOption sets a slice field from pflag or env var.
-// For env vars, values are comma-separated (e.g. APP_TAGS=foo,bar,baz).
-func (t *implCliApplication) setSliceOption(flagSet *pflag.FlagSet, f *pflag.Flag, field reflect.Value, envVars map[string]string) {
-	elemKind := field.Type().Elem().Kind()
-
-	// If flag not explicitly set, try environment variable
-	if !flagSet.Changed(f.Name) {
-		if envVar, ok := envVars[f.Name]; ok {
-			if envValue := os.Getenv(envVar); envValue != "" {
-				parts := strings.Split(envValue, ",")
-				switch elemKind {
-				case reflect.String:
-					field.Set(reflect.ValueOf(parts))
-				case reflect.Int:
-					vals := make([]int, 0, len(parts))
-					for _, p := range parts {
-						v, _ := strconv.Atoi(strings.TrimSpace(p))
-						vals = append(vals, v)
-					}
-					field.Set(reflect.ValueOf(vals))
-				case reflect.Float64:
-					vals := make([]float64, 0, len(parts))
-					for _, p := range parts {
-						v, _ := strconv.ParseFloat(strings.TrimSpace(p), 64)
-						vals = append(vals, v)
-					}
-					field.Set(reflect.ValueOf(vals))
-				case reflect.Bool:
-					vals := make([]bool, 0, len(parts))
-					for _, p := range parts {
-						v, _ := strconv.ParseBool(strings.TrimSpace(p))
-						vals = append(vals, v)
-					}
-					field.Set(reflect.ValueOf(vals))
-				}
-				return
+type optInfo struct {
+	field    reflect.Value
+	env      string
+	layout   string
+	required bool
+	choices  []string
+	xor      string
+	requires []string
+	property string
+
+	// propertyValue is the value of property, resolved in executeCommand, see resolveOptionProperties
+	propertyValue string
+	hasProperty   bool
+
+	constraints constraints
+}
+
+// fallback returns the value of an option not given on the command line: its environment variable,
+// or else its property. from describes the source for error messages, e.g. " from $APP_PORT".
+func (opt optInfo) fallback() (value string, from string, ok bool) {
+	if opt.env != "" {
+		if envValue := os.Getenv(opt.env); envValue != "" {
+			return envValue, " from $" + opt.env, true
+		}
+	}
+	if opt.hasProperty {
+		return opt.propertyValue, " from property " + opt.property, true
+	}
+	return "", "", false
+}
+
+// optionSupplied reports whether the option was given on the command line, through its environment variable or its property.
+func optionSupplied(flagSet *pflag.FlagSet, name string, opt optInfo) bool {
+	if flagSet.Changed(name) {
+		return true
+	}
+	_, _, ok := opt.fallback()
+	return ok
+}
+
+// resolveOptionProperties looks up the property= keys of options in props, which include the
+// config files, active profiles and -D overrides. Empty values count as unset, like empty env vars.
+func resolveOptionProperties(props glue.Properties, options map[string]optInfo) {
+	for name, opt := range options {
+		if opt.property == "" || props == nil {
+			continue
+		}
+		if value, ok := props.Get(opt.property); ok && value != "" {
+			opt.propertyValue, opt.hasProperty = value, true
+			options[name] = opt
+		}
+	}
+}
+
+// setSliceOption sets a slice field from pflag, env var or property.
+// For env vars and properties, values are comma-separated (e.g. APP_TAGS=foo,bar,baz).
+func (t *implCliApplication) setSliceOption(flagSet *pflag.FlagSet, f *pflag.Flag, opt optInfo) error {
+	if flagSet.Changed(f.Name) {
+		value := f.Value.(*sliceValue)
+		for _, raw := range value.raw {
+			if err := choiceError("option --"+f.Name, raw, opt.choices); err != nil {
+				return err
 			}
 		}
-		return
+		opt.field.Set(value.values)
+		return nil
 	}
 
-	switch elemKind {
-	case reflect.String:
-		vals, _ := flagSet.GetStringArray(f.Name)
-		field.Set(reflect.ValueOf(vals))
-	case reflect.Int:
-		vals, _ := flagSet.GetIntSlice(f.Name)
-		field.Set(reflect.ValueOf(vals))
-	case reflect.Float64:
-		vals, _ := flagSet.GetFloat64Slice(f.Name)
-		field.Set(reflect.ValueOf(vals))
-	case reflect.Bool:
-		vals, _ := flagSet.GetBoolSlice(f.Name)
-		field.Set(reflect.ValueOf(vals))
+	// If flag not explicitly set, try environment variable and property
+	envValue, from, ok := opt.fallback()
+	if !ok {
+		return nil
+	}
+	elemType := opt.field.Type().Elem()
+	parts := strings.Split(envValue, ",")
+	vals := reflect.MakeSlice(opt.field.Type(), len(parts), len(parts))
+	for i, p := range parts {
+		if elemType.Kind() != reflect.String {
+			p = strings.TrimSpace(p)
+		}
+		if err := choiceError("option --"+f.Name+from, p, opt.choices); err != nil {
+			return err
+		}
+		if err := setFieldFromString(vals.Index(i), p, opt.layout); err != nil {
+			return usageErrorf("invalid %s for option --%s%s: %s", valueTypeName(elemType), f.Name, from, p)
+		}
 	}
+	opt.field.Set(vals)
+	return nil
+}
+
+// setMapOption sets a map field from pflag, env var or property.
+// For env vars and properties, entries are comma-separated key=value pairs (e.g. APP_LABELS=team=core,tier=web).
+func (t *implCliApplication) setMapOption(flagSet *pflag.FlagSet, f *pflag.Flag, opt optInfo) error {
+	if flagSet.Changed(f.Name) {
+		opt.field.Set(f.Value.(*mapValue).values)
+		return nil
+	}
+
+	// If flag not explicitly set, try environment variable and property
+	envValue, from, ok := opt.fallback()
+	if !ok {
+		return nil
+	}
+	elemType := opt.field.Type().Elem()
+	vals := reflect.MakeMap(opt.field.Type())
+	for _, entry := range strings.Split(envValue, ",") {
+		if elemType.Kind() != reflect.String {
+			entry = strings.TrimSpace(entry)
+		}
+		if err := setMapEntry(vals, entry, opt.layout); err != nil {
+			return usageErrorf("invalid entry for option --%s%s: %v", f.Name, from, err)
+		}
+	}
+	opt.field.Set(vals)
+	return nil
 }
 
 // setFieldFromString sets a reflect.Value from a string, handling type conversion.
-func setFieldFromString(field reflect.Value, value string) {
+// Custom types parse the value themselves, see isCustomType. Time values are parsed
+// with layout, or with timeLayouts when layout is empty.
+// The field is left unchanged when the value does not parse or overflows the field type.
+func setFieldFromString(field reflect.Value, value string, layout string) error {
+	switch field.Type() {
+	case durationType:
+		val, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(val))
+		return nil
+	case timeType:
+		val, err := parseTime(value, layout)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(val))
+		return nil
+	}
+	if isCustomType(field.Type()) {
+		return setCustomFromString(field, value)
+	}
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		val, _ := strconv.ParseInt(value, 10, 64)
+		val, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
 		field.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(val)
 	case reflect.Float32, reflect.Float64:
-		val, _ := strconv.ParseFloat(value, 64)
+		val, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
 		field.SetFloat(val)
 	case reflect.Bool:
-		val, _ := strconv.ParseBool(value)
+		val, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
 		field.SetBool(val)
 	}
+	return nil
 }
 
 // extractParentInfo extracts group, hidden, and alias metadata from the CliGroup parent field.
@@ -125,109 +233,269 @@ func extractParentGroup(obj interface{}) string {
 	return extractParentInfo(obj).group
 }
 
-func (t *implCliApplication) setOptionValues(flagSet *pflag.FlagSet, options map[string]reflect.Value, envVars map[string]string) {
+// valueTypeName names the type of value a field expects, for parse error messages.
+func valueTypeName(typ reflect.Type) string {
+	switch typ {
+	case durationType:
+		return "duration"
+	case timeType:
+		return "time"
+	}
+	if isCustomType(typ) {
+		return customTypeName(typ)
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "unsigned integer"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "boolean"
+	}
+	return typ.String()
+}
+
+// setOptionValues binds option fields with priority: explicit flag > env var > property > default.
+// A value that does not parse into the field type is reported as a usage error naming the option and its source,
+// and required options that were not supplied are reported together in one usage error,
+// as are options breaking their xor= and requires= relations, see optionRelationError.
+func (t *implCliApplication) setOptionValues(flagSet *pflag.FlagSet, options map[string]optInfo, cmd CliCommand, stack []string) error {
+	var firstErr error
+	var missing []string
 	flagSet.VisitAll(func(f *pflag.Flag) {
-		field, ok := options[f.Name]
-		if !ok {
+		opt, ok := options[f.Name]
+		if !ok || firstErr != nil {
 			return
 		}
 
-		if field.Kind() == reflect.Slice {
-			t.setSliceOption(flagSet, f, field, envVars)
+		if opt.required && !optionSupplied(flagSet, f.Name, opt) {
+			missing = append(missing, "--"+f.Name)
+			return
+		}
+
+		if _, ok := f.Value.(*sliceValue); ok {
+			firstErr = t.setSliceOption(flagSet, f, opt)
+			return
+		}
+		if _, ok := f.Value.(*mapValue); ok {
+			firstErr = t.setMapOption(flagSet, f, opt)
 			return
 		}
 
 		value := f.Value.String()
+		from := ""
 
-		// If flag not explicitly set, try environment variable
+		// If flag not explicitly set, try environment variable and property
 		if !flagSet.Changed(f.Name) {
-			if envVar, ok := envVars[f.Name]; ok {
-				if envValue := os.Getenv(envVar); envValue != "" {
-					value = envValue
-				}
+			if fallback, fallbackFrom, ok := opt.fallback(); ok {
+				value, from = fallback, fallbackFrom
+			}
+		}
+
+		// A text value without flag, env var or default keeps the field's zero value
+		if _, ok := f.Value.(*textValue); ok && value == "" {
+			return
+		}
+
+		// Defaults are checked against the choices at registration
+		if optionSupplied(flagSet, f.Name, opt) {
+			if firstErr = choiceError("option --"+f.Name+from, value, opt.choices); firstErr != nil {
+				return
 			}
 		}
 
-		setFieldFromString(field, value)
+		if err := setFieldFromString(opt.field, value, opt.layout); err != nil {
+			firstErr = usageErrorf("invalid %s for option --%s%s: %s", valueTypeName(opt.field.Type()), f.Name, from, value)
+		}
 	})
+	if firstErr == nil && len(missing) == 1 {
+		firstErr = usageErrorf("missing required option '%s'", missing[0])
+	} else if firstErr == nil && len(missing) > 1 {
+		firstErr = usageErrorf("missing required options '%s'", strings.Join(missing, "', '"))
+	}
+	if firstErr == nil {
+		firstErr = optionRelationError(flagSet, options)
+	}
+	if firstErr != nil {
+		t.Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
+	}
+	return firstErr
+}
+
+// isVariadicType reports whether an argument of type typ consumes all remaining positional values.
+func isVariadicType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && !isCustomType(typ)
+}
+
+// parseArity parses the minargs= and maxargs= tags of a variadic argument. Like other arguments,
+// a variadic argument is required by default: min is 1 unless set. A max of 0 means no limit.
+func parseArity(tagParts map[string]string) (min int, max int, err error) {
+	min = 1
+	if val, ok := tagParts["minargs"]; ok {
+		if min, err = strconv.Atoi(val); err != nil || min < 0 {
+			return 0, 0, xerrors.Errorf("invalid minargs %q, expected a non-negative integer", val)
+		}
+	}
+	if val, ok := tagParts["maxargs"]; ok {
+		if max, err = strconv.Atoi(val); err != nil || max < 1 {
+			return 0, 0, xerrors.Errorf("invalid maxargs %q, expected a positive integer", val)
+		}
+		if max < min {
+			return 0, 0, xerrors.Errorf("maxargs %d is less than minargs %d", max, min)
+		}
+	}
+	return min, max, nil
 }
 
 func (t *implCliApplication) setArgumentValues(argDefs []argInfo, cmdValue reflect.Value, argValues []string, cmd CliCommand, stack []string) error {
-	argIndex := 0
-	for _, arg := range argDefs {
-		field := cmdValue.Field(arg.position)
-		if argIndex >= len(argValues) {
-			if arg.required {
-				Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
-				return xerrors.Errorf("missing required argument '%s'", arg.name)
-			}
-			if arg.defVal != "" {
-				setFieldFromString(field, arg.defVal)
-			}
-			continue
+	err := bindArguments(argDefs, cmdValue, argValues)
+	if err != nil {
+		t.Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
+	}
+	return err
+}
+
+// bindArguments binds positional values to the argument fields. A variadic argument takes
+// the values left after the arguments before and after it; without one, extra values are an error.
+func bindArguments(argDefs []argInfo, cmdValue reflect.Value, argValues []string) error {
+	variadic := -1
+	for i, arg := range argDefs {
+		if arg.variadic {
+			variadic = i
 		}
+	}
 
-		switch field.Kind() {
-		case reflect.String:
-			field.SetString(argValues[argIndex])
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			val, err := strconv.ParseInt(argValues[argIndex], 10, 64)
-			if err != nil {
-				Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
-				return xerrors.Errorf("invalid integer for argument %s: %s", arg.name, argValues[argIndex])
+	if variadic < 0 {
+		for i, arg := range argDefs {
+			field := cmdValue.Field(arg.position)
+			if i >= len(argValues) {
+				if arg.required {
+					return usageErrorf("missing required argument '%s'", arg.name)
+				}
+				if arg.defVal != "" {
+					if err := setFieldFromString(field, arg.defVal, arg.layout); err != nil {
+						return usageErrorf("invalid %s for argument %s from default: %s", valueTypeName(field.Type()), arg.name, arg.defVal)
+					}
+				}
+				continue
 			}
-			field.SetInt(val)
-		case reflect.Float32, reflect.Float64:
-			val, err := strconv.ParseFloat(argValues[argIndex], 64)
-			if err != nil {
-				Echo("%s\n%s\n", t.getCommandUsage(cmd, stack), t.getCommandTryUsage(cmd, stack))
-				return xerrors.Errorf("invalid float for argument %s: %s", arg.name, argValues[argIndex])
+			if err := bindArgument(field, arg, argValues[i]); err != nil {
+				return err
 			}
-			field.SetFloat(val)
 		}
-		argIndex++
+		if len(argValues) > len(argDefs) {
+			return usageErrorf("unexpected argument '%s'", argValues[len(argDefs)])
+		}
+		return nil
+	}
+
+	// Arguments around a variadic one are all required, see validateCliTags
+	before, arg, after := argDefs[:variadic], argDefs[variadic], argDefs[variadic+1:]
+	count := len(argValues) - len(before) - len(after)
+	switch {
+	case len(argValues) < len(before):
+		return usageErrorf("missing required argument '%s'", before[len(argValues)].name)
+	case count <= 0 && arg.min > 0:
+		return usageErrorf("missing required argument '%s'", arg.name)
+	case count < 0:
+		return usageErrorf("missing required argument '%s'", after[len(argValues)-len(before)].name)
+	case count < arg.min:
+		return usageErrorf("argument '%s' expects at least %d values, got %d", arg.name, arg.min, count)
+	case arg.max > 0 && count > arg.max:
+		return usageErrorf("argument '%s' expects at most %d values, got %d", arg.name, arg.max, count)
+	}
+
+	for i, def := range before {
+		if err := bindArgument(cmdValue.Field(def.position), def, argValues[i]); err != nil {
+			return err
+		}
+	}
+	field := cmdValue.Field(arg.position)
+	vals := reflect.MakeSlice(field.Type(), count, count)
+	for i := 0; i < count; i++ {
+		if err := bindArgument(vals.Index(i), arg, argValues[len(before)+i]); err != nil {
+			return err
+		}
+	}
+	field.Set(vals)
+	for i, def := range after {
+		if err := bindArgument(cmdValue.Field(def.position), def, argValues[len(before)+count+i]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (t *implCliApplication) identifyArgumentsAndOptions(cmdType reflect.Type, cmdValue reflect.Value, flagSet *pflag.FlagSet) ([]argInfo, map[string]reflect.Value, map[string]string) {
-	var argDefs []argInfo
-	options := make(map[string]reflect.Value)
-	envVars := make(map[string]string)
+// bindArgument sets one positional value, reporting a value outside the choices or one that does not parse as a usage error.
+func bindArgument(field reflect.Value, arg argInfo, value string) error {
+	if err := choiceError("argument "+arg.name, value, arg.choices); err != nil {
+		return err
+	}
+	if err := setFieldFromString(field, value, arg.layout); err != nil {
+		return usageErrorf("invalid %s for argument %s: %s", valueTypeName(field.Type()), arg.name, value)
+	}
+	return nil
+}
 
-	for i := 0; i < cmdType.NumField(); i++ {
-		field := cmdType.Field(i)
-		cliTag := field.Tag.Get("cli")
-		if cliTag == "" {
-			continue
-		}
+func (t *implCliApplication) identifyArgumentsAndOptions(cmdType reflect.Type, cmdValue reflect.Value, flagSet *pflag.FlagSet) ([]argInfo, map[string]optInfo) {
+	var argDefs []argInfo
+	options := make(map[string]optInfo)
 
-		tagParts := parseCliTag(cliTag)
+	for _, field := range t.expandedCliFields(cmdType) {
+		tagParts := field.tagParts
 
-		// Handle argument
-		if argName, ok := tagParts["argument"]; ok {
+		// Handle argument, declared on the command itself, see validateCliTags
+		if argName, ok := tagParts["argument"]; ok && !field.embedded() {
 			_, hasDefault := tagParts["default"]
 			_, hasRequired := tagParts["required"]
-			argDefs = append(argDefs, argInfo{
+			arg := argInfo{
 				name:     argName,
-				position: i,
+				position: field.Index[0],
 				required: !hasDefault || hasRequired,
 				defVal:   tagParts["default"],
-			})
+				layout:   tagParts["layout"],
+				choices:  parseChoices(tagParts),
+			}
+			if isVariadicType(field.Type) {
+				arg.variadic = true
+				arg.min, arg.max, _ = parseArity(tagParts)
+				arg.required = arg.min > 0
+				arg.constraints, _ = parseConstraints(field.Type.Elem(), tagParts)
+			} else {
+				arg.constraints, _ = parseConstraints(field.Type, tagParts)
+			}
+			argDefs = append(argDefs, arg)
 			continue
 		}
 
 		// Handle option
 		if optName, ok := tagParts["option"]; ok {
-			fieldVal := cmdValue.Field(i)
-			options[optName] = fieldVal
+			fieldVal := cmdValue.FieldByIndex(field.Index)
+			_, hasRequired := tagParts["required"]
+			opt := optInfo{
+				field:    fieldVal,
+				env:      tagParts["env"],
+				layout:   tagParts["layout"],
+				required: hasRequired,
+				choices:  parseChoices(tagParts),
+				xor:      tagParts["xor"],
+				requires: parseRequires(tagParts),
+				property: tagParts["property"],
+			}
+			if isVariadicType(field.Type) {
+				opt.constraints, _ = parseConstraints(field.Type.Elem(), tagParts)
+			} else {
+				opt.constraints, _ = parseConstraints(field.Type, tagParts)
+			}
+			options[optName] = opt
 
 			shortFlag := strings.TrimPrefix(tagParts["short"], "-")
 			helpText := tagParts["help"]
 
-			// Track environment variable binding
+			// Mention the environment variable binding in the flag usage
 			if envVar, ok := tagParts["env"]; ok {
-				envVars[optName] = envVar
 				if helpText != "" {
 					helpText = helpText + " [$" + envVar + "]"
 				} else {
@@ -236,6 +504,11 @@ func (t *implCliApplication) identifyArgumentsAndOptions(cmdType reflect.Type, c
 			}
 
 			// Register flag with the flag set based on field type
+			if isTextValueType(fieldVal.Type()) {
+				value := &textValue{typ: fieldVal.Type(), layout: tagParts["layout"], value: tagParts["default"]}
+				flagSet.VarP(value, optName, shortFlag, helpText)
+				continue
+			}
 			switch fieldVal.Kind() {
 			case reflect.String:
 				defaultVal := tagParts["default"]
@@ -245,6 +518,10 @@ func (t *implCliApplication) identifyArgumentsAndOptions(cmdType reflect.Type, c
 					flagSet.String(optName, defaultVal, helpText)
 				}
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if _, ok := tagParts["count"]; ok {
+					flagSet.CountP(optName, shortFlag, helpText)
+					continue
+				}
 				defaultVal := 0
 				if val, ok := tagParts["default"]; ok {
 					defaultVal, _ = strconv.Atoi(val)
@@ -267,42 +544,25 @@ func (t *implCliApplication) identifyArgumentsAndOptions(cmdType reflect.Type, c
 			case reflect.Bool:
 				defaultVal := false
 				if val, ok := tagParts["default"]; ok {
-					defaultVal = val == "true"
+					defaultVal, _ = strconv.ParseBool(val)
 				}
 				if shortFlag != "" {
 					flagSet.BoolP(optName, shortFlag, defaultVal, helpText)
 				} else {
 					flagSet.Bool(optName, defaultVal, helpText)
 				}
-			case reflect.Slice:
-				switch fieldVal.Type().Elem().Kind() {
-				case reflect.String:
-					if shortFlag != "" {
-						flagSet.StringArrayP(optName, shortFlag, nil, helpText)
-					} else {
-						flagSet.StringArray(optName, nil, helpText)
-					}
-				case reflect.Int:
-					if shortFlag != "" {
-						flagSet.IntSliceP(optName, shortFlag, nil, helpText)
-					} else {
-						flagSet.IntSlice(optName, nil, helpText)
-					}
-				case reflect.Float64:
-					if shortFlag != "" {
-						flagSet.Float64SliceP(optName, shortFlag, nil, helpText)
-					} else {
-						flagSet.Float64Slice(optName, nil, helpText)
-					}
-				case reflect.Bool:
-					if shortFlag != "" {
-						flagSet.BoolSliceP(optName, shortFlag, nil, helpText)
-					} else {
-						flagSet.BoolSlice(optName, nil, helpText)
-					}
+				if _, ok := tagParts["negatable"]; ok {
+					negated := flagSet.VarPF(&negatedValue{flagSet: flagSet, name: optName}, "no-"+optName, "", "Disable --"+optName)
+					negated.NoOptDefVal = "true"
 				}
+			case reflect.Slice:
+				value := &sliceValue{values: reflect.MakeSlice(fieldVal.Type(), 0, 0), layout: tagParts["layout"]}
+				flagSet.VarP(value, optName, shortFlag, helpText)
+			case reflect.Map:
+				value := &mapValue{values: reflect.MakeMap(fieldVal.Type()), layout: tagParts["layout"]}
+				flagSet.VarP(value, optName, shortFlag, helpText)
 			}
 		}
 	}
-	return argDefs, options, envVars
+	return argDefs, options
 }
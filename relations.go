@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// parseRequires splits the requires= tag (user|password) into option names, or returns nil without the tag.
+func parseRequires(tagParts map[string]string) []string {
+	val, ok := tagParts["requires"]
+	if !ok {
+		return nil
+	}
+	return strings.Split(val, "|")
+}
+
+// optionRelationError reports options given together although they share an xor= group, and
+// options given without the options they require, in one usage error. An option counts as
+// given when it was set on the command line or through its environment variable.
+func optionRelationError(flagSet *pflag.FlagSet, options map[string]optInfo) error {
+	var problems []string
+	groups := make(map[string][]string)
+	flagSet.VisitAll(func(f *pflag.Flag) {
+		opt, ok := options[f.Name]
+		if !ok || !optionSupplied(flagSet, f.Name, opt) {
+			return
+		}
+		if opt.xor != "" {
+			groups[opt.xor] = append(groups[opt.xor], "--"+f.Name)
+		}
+		for _, name := range opt.requires {
+			if !optionSupplied(flagSet, name, options[name]) {
+				problems = append(problems, "option '--"+f.Name+"' requires '--"+name+"'")
+			}
+		}
+	})
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var exclusive []string
+	for _, name := range names {
+		if given := groups[name]; len(given) > 1 {
+			exclusive = append(exclusive, "mutually exclusive options '"+strings.Join(given, "', '")+"' given together")
+		}
+	}
+
+	problems = append(exclusive, problems...)
+	if len(problems) == 0 {
+		return nil
+	}
+	return usageErrorf("%s", strings.Join(problems, "; "))
+}
+
+// optionRelationTexts returns the help annotations of every option declared on cmdType by name:
+// the other options of its xor= group and the options it requires.
+func optionRelationTexts(cmdType reflect.Type) map[string]string {
+	groups := make(map[string][]string)
+	xor := make(map[string]string)
+	texts := make(map[string]string)
+	for _, field := range cliFields(cmdType) {
+		tagParts := field.tagParts
+		optName, ok := tagParts["option"]
+		if !ok {
+			continue
+		}
+		if group := tagParts["xor"]; group != "" {
+			groups[group] = append(groups[group], "--"+optName)
+			xor[optName] = group
+		}
+		if requires := parseRequires(tagParts); requires != nil {
+			texts[optName] = " [requires --" + strings.Join(requires, ", --") + "]"
+		}
+	}
+	for optName, group := range xor {
+		var others []string
+		for _, name := range groups[group] {
+			if name != "--"+optName {
+				others = append(others, name)
+			}
+		}
+		if len(others) > 0 {
+			texts[optName] = " [excludes " + strings.Join(others, ", ") + "]" + texts[optName]
+		}
+	}
+	return texts
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// ─── xor= and requires= ──────────────────────────────────────────────────────
+
+func TestOptionRelations_Satisfied(t *testing.T) {
+	t.Setenv("SOURCE_PASSWORD", "")
+	for _, args := range [][]string{
+		{"import"},
+		{"import", "--file=a.csv"},
+		{"import", "--stdin", "--user=bob", "--password=secret"},
+	} {
+		cmd := &sourceCmd{}
+		if err := Run(Args(args), Beans(cmd)); err != nil {
+			t.Errorf("%v: unexpected error: %v", args, err)
+		} else if !cmd.ran {
+			t.Errorf("%v: expected command to run", args)
+		}
+	}
+
+	t.Setenv("SOURCE_PASSWORD", "secret")
+	if err := Run(Args([]string{"import", "--user=bob"}), Beans(&sourceCmd{})); err != nil {
+		t.Errorf("expected password from env to satisfy requires=, got: %v", err)
+	}
+}
+
+func TestOptionRelations_Violated(t *testing.T) {
+	t.Setenv("SOURCE_PASSWORD", "")
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"import", "--file=a.csv", "--stdin"}, "mutually exclusive options '--file', '--stdin' given together"},
+		{[]string{"import", "--stdin", "--url=x", "--file=a"}, "mutually exclusive options '--file', '--stdin', '--url' given together"},
+		{[]string{"import", "--user=bob"}, "option '--user' requires '--password'"},
+		{[]string{"import", "--stdin", "--url=x", "--user=bob"}, "mutually exclusive options '--stdin', '--url' given together; option '--user' requires '--password'"},
+	} {
+		var out bytes.Buffer
+		cmd := &sourceCmd{}
+		err := Run(Args(tc.args), Stdout(&out), Beans(cmd))
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%v: expected usage error, got: %v", tc.args, err)
+			continue
+		}
+		if err.Error() != tc.want {
+			t.Errorf("%v: expected %q, got: %v", tc.args, tc.want, err)
+		}
+		if cmd.ran {
+			t.Errorf("%v: command should not run", tc.args)
+		}
+		if !strings.Contains(out.String(), "Usage:") {
+			t.Errorf("%v: expected usage line, got:\n%s", tc.args, out.String())
+		}
+	}
+}
+
+func TestOptionRelations_Help(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"import", "--help"}), Stdout(&out), Beans(&sourceCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"Read from file [excludes --stdin, --url]",
+		"Read from stdin [excludes --file, --url]",
+		"User name [requires --password]",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in help, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestValidateCliTags_Relations(t *testing.T) {
+	type badRelationsCmd struct {
+		Name  string `cli:"argument=name,xor=x"`
+		File  string `cli:"option=file,xor=source"`
+		User  string `cli:"option=user,requires=pass"`
+		Token string `cli:"option=token,requires=token"`
+	}
+	err := validateCliTags(&badRelationsCmd{})
+	if err == nil {
+		t.Fatal("expected error for relation tags")
+	}
+	for _, want := range []string{
+		"badRelationsCmd.Name: xor= applies only to options",
+		`badRelationsCmd.File: xor group "source" has no other option`,
+		"badRelationsCmd.User: requires unknown option --pass",
+		"badRelationsCmd.Token: option --token requires itself",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
@@ -46,8 +46,7 @@ func levenshtein(a, b string) int {
 
 // suggest returns the closest matching command or group name for the given
 // input within the specified parent group. It returns "" if no reasonable
-// match is found (distance must be at most half the input length, with a
-// minimum threshold of 2).
+// match is found, see closest.
 func (t *implCliApplication) suggest(parentGroup, input string) string {
 	var candidates []string
 
@@ -68,6 +67,13 @@ func (t *implCliApplication) suggest(parentGroup, input string) string {
 		}
 	}
 
+	return closest(input, candidates)
+}
+
+// closest returns the candidate nearest to input by edit distance, or "" if none is
+// close enough: the distance must be at most half the input length, with a minimum
+// threshold of 2.
+func closest(input string, candidates []string) string {
 	best := ""
 	bestDist := -1
 	for _, c := range candidates {
@@ -0,0 +1,442 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.arpabet.com/glue"
+	"golang.org/x/xerrors"
+)
+
+// parentTagKeys lists the keys accepted in the cli tag of the CliGroup parent field.
+var parentTagKeys = map[string]bool{
+	"group":  true,
+	"hidden": true,
+	"alias":  true,
+}
+
+// fieldTagKeys lists the keys accepted in the cli tag of an argument or option field.
+var fieldTagKeys = map[string]bool{
+	"argument":  true,
+	"option":    true,
+	"short":     true,
+	"help":      true,
+	"default":   true,
+	"required":  true,
+	"env":       true,
+	"layout":    true,
+	"min":       true,
+	"max":       true,
+	"minargs":   true,
+	"maxargs":   true,
+	"choices":   true,
+	"pattern":   true,
+	"exists":    true,
+	"xor":       true,
+	"requires":  true,
+	"negatable": true,
+	"count":     true,
+	"property":  true,
+}
+
+// reservedOptions are the options every command registers itself, by long and short name.
+var reservedOptions = map[string]bool{
+	"help":    true,
+	"verbose": true,
+	"h":       true,
+}
+
+// validateCliTags checks every cli tag of obj, including those of embedded option sets, and
+// reports all problems found in one error, each naming the struct and field: malformed tags,
+// unknown keys, unsupported field types, unparseable defaults or defaults that break min=, max=
+// or pattern=, invalid constraint tags, duplicate option names or short flags, xor= groups with
+// a single option, requires= naming an unknown option, and a required argument placed after an
+// optional one. Placeholders in default= and help= are expanded against the environment only,
+// see validateExpandedCliTags.
+func validateCliTags(obj interface{}) error {
+	return validateExpandedCliTags(obj, nil)
+}
+
+// validateExpandedCliTags is validateCliTags with the placeholders in default= and help= expanded
+// against props as well, so defaults taken from properties are checked like literal ones.
+func validateExpandedCliTags(obj interface{}, props glue.Properties) error {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var problems []string
+	report := func(field cliField, format string, args ...interface{}) {
+		problems = append(problems, typ.Name()+"."+field.path+": "+fmt.Sprintf(format, args...))
+	}
+	// minargs= and maxargs= count the values of a variadic argument, nothing else has a count
+	reportArity := func(field cliField) {
+		_, hasMin := field.tagParts["minargs"]
+		_, hasMax := field.tagParts["maxargs"]
+		if hasMin || hasMax {
+			report(field, "minargs= and maxargs= apply only to variadic arguments")
+		}
+	}
+
+	arguments := make(map[string]bool)
+	options := make(map[string]string)
+	shorts := make(map[string]string)
+	optionalArg := ""
+	variadicArg := ""
+	xorGroups := make(map[string][]cliField)
+	type requirement struct {
+		field cliField
+		names []string
+	}
+	var requirements []requirement
+	type negatedName struct {
+		field cliField
+		name  string
+	}
+	var negatedNames []negatedName
+	_, isGroup := obj.(CliGroup)
+
+	for _, field := range expandCliFields(cliFields(typ), props) {
+		if field.err != nil {
+			report(field, "%v", field.err)
+			continue
+		}
+		tagParts := field.tagParts
+
+		if field.Type == CliGroupClass {
+			if field.embedded() {
+				report(field, "parent group field in embedded struct")
+			}
+			for _, key := range unknownTagKeys(tagParts, parentTagKeys) {
+				report(field, "unknown key %q in parent tag", key)
+			}
+			continue
+		}
+
+		if _, ok := tagParts["embed"]; ok {
+			for _, key := range unknownTagKeys(tagParts, embedTagKeys) {
+				report(field, "unknown key %q in embed tag", key)
+			}
+			if field.Type.Kind() != reflect.Struct {
+				report(field, "embed applies only to struct fields, got %s", field.Type)
+			} else if !field.IsExported() && !field.Anonymous {
+				report(field, "field must be exported")
+			}
+			continue
+		}
+
+		for _, key := range unknownTagKeys(tagParts, fieldTagKeys) {
+			report(field, "unknown key %q", key)
+		}
+
+		argName, isArg := tagParts["argument"]
+		optName, isOpt := tagParts["option"]
+		switch {
+		case isArg && isOpt:
+			report(field, "both argument= and option= are set")
+			continue
+		case !isArg && !isOpt:
+			report(field, "missing argument= or option=")
+			continue
+		}
+		if isArg && isGroup {
+			report(field, "arguments are not supported on groups")
+			continue
+		}
+		if !field.IsExported() {
+			report(field, "field must be exported")
+			continue
+		}
+		if _, ok := tagParts["layout"]; ok && field.Type != timeType && (field.Type.Kind() != reflect.Slice || field.Type.Elem() != timeType) {
+			report(field, "layout= applies only to time.Time fields")
+		}
+
+		if field.Type.Kind() == reflect.Map && !isCustomType(field.Type) {
+			for _, key := range []string{"choices", "min", "max", "pattern", "exists"} {
+				if _, ok := tagParts[key]; ok {
+					report(field, "%s= is not supported for map options", key)
+					delete(tagParts, key)
+				}
+			}
+		}
+
+		if choices := parseChoices(tagParts); choices != nil {
+			elemType := field.Type
+			if isVariadicType(elemType) {
+				elemType = elemType.Elem()
+			}
+			for _, choice := range choices {
+				if choice == "" {
+					report(field, "empty value in choices %q", tagParts["choices"])
+				} else if err := setFieldFromString(reflect.New(elemType).Elem(), choice, tagParts["layout"]); err != nil {
+					report(field, "invalid choice %q for %s", choice, elemType)
+				}
+			}
+			if defVal, ok := tagParts["default"]; ok && choiceError("default", defVal, choices) != nil {
+				report(field, "default %q is not one of the choices %q", defVal, tagParts["choices"])
+			}
+		}
+
+		if isArg {
+			if field.embedded() {
+				report(field, "arguments are not supported in embedded structs")
+				continue
+			}
+			for _, key := range []string{"xor=", "requires=", "negatable", "count", "property="} {
+				if _, ok := tagParts[strings.TrimSuffix(key, "=")]; ok {
+					report(field, "%s applies only to options", key)
+				}
+			}
+			if argName == "" || argName == "true" {
+				report(field, "empty argument name")
+			} else if arguments[argName] {
+				report(field, "duplicate argument %q", argName)
+			}
+			arguments[argName] = true
+
+			if !isArgumentKind(field.Type) {
+				report(field, "unsupported argument type %s", field.Type)
+				continue
+			}
+			if !isVariadicType(field.Type) {
+				reportArity(field)
+			}
+			defVal, hasDefault := tagParts["default"]
+			_, hasRequired := tagParts["required"]
+			if isVariadicType(field.Type) {
+				if variadicArg != "" {
+					report(field, "variadic argument %q follows variadic argument %q", argName, variadicArg)
+				}
+				if optionalArg != "" {
+					report(field, "variadic argument %q follows optional argument %q", argName, optionalArg)
+				}
+				variadicArg = argName
+				if hasDefault {
+					report(field, "default= is not supported for variadic arguments")
+				}
+				if _, _, err := parseArity(tagParts); err != nil {
+					report(field, "%v", err)
+				}
+				// min= and max= once set the arity of a variadic argument, so without minargs= or
+				// maxargs= they more likely mean the count than a range of the values
+				_, hasMinArgs := tagParts["minargs"]
+				_, hasMaxArgs := tagParts["maxargs"]
+				_, hasMin := tagParts["min"]
+				_, hasMax := tagParts["max"]
+				if (hasMin || hasMax) && !hasMinArgs && !hasMaxArgs {
+					report(field, "min= and max= bound the values of variadic argument %q, not their number: use minargs= and maxargs= for the number, or give one of them to keep the value range", argName)
+				}
+				if _, err := parseConstraints(field.Type.Elem(), tagParts); err != nil {
+					report(field, "%v", err)
+				}
+				continue
+			}
+			c, err := parseConstraints(field.Type, tagParts)
+			if err != nil {
+				report(field, "%v", err)
+			}
+			if hasDefault {
+				value := reflect.New(field.Type).Elem()
+				if err := setFieldFromString(value, defVal, tagParts["layout"]); err != nil {
+					report(field, "invalid default %q for %s", defVal, field.Type)
+				} else if msg := c.rangeViolation(value); msg != "" {
+					report(field, "default %q %s", defVal, msg)
+				}
+			}
+			if !hasDefault || hasRequired {
+				if optionalArg != "" {
+					report(field, "required argument %q follows optional argument %q", argName, optionalArg)
+				}
+			} else {
+				if variadicArg != "" {
+					report(field, "optional argument %q follows variadic argument %q", argName, variadicArg)
+				}
+				if optionalArg == "" {
+					optionalArg = argName
+				}
+			}
+			continue
+		}
+
+		if optName == "" || optName == "true" {
+			report(field, "empty option name")
+		} else if reservedOptions[optName] {
+			report(field, "option --%s is reserved", optName)
+		} else if other, ok := options[optName]; ok {
+			report(field, "duplicate option --%s, already declared by %s", optName, other)
+		}
+		options[optName] = field.path
+		reportArity(field)
+		if property, ok := tagParts["property"]; ok && (property == "" || property == "true") {
+			report(field, "empty property name")
+		}
+		if group, ok := tagParts["xor"]; ok {
+			if group == "" || group == "true" {
+				report(field, "empty xor group")
+			} else {
+				xorGroups[group] = append(xorGroups[group], field)
+			}
+		}
+		if requires := parseRequires(tagParts); requires != nil {
+			requirements = append(requirements, requirement{field, requires})
+		}
+		if _, ok := tagParts["negatable"]; ok {
+			if field.Type.Kind() != reflect.Bool || isCustomType(field.Type) {
+				report(field, "negatable applies only to bool options")
+			} else {
+				negatedNames = append(negatedNames, negatedName{field, "no-" + optName})
+			}
+		}
+		if _, ok := tagParts["count"]; ok {
+			if !isCountKind(field.Type) {
+				report(field, "count applies only to int options")
+			} else if _, ok := tagParts["default"]; ok {
+				report(field, "default= is not supported for count options")
+			}
+		}
+
+		if short, ok := tagParts["short"]; ok {
+			short = strings.TrimPrefix(short, "-")
+			if len(short) != 1 {
+				report(field, "short flag %q must be a single character", tagParts["short"])
+			} else if reservedOptions[short] {
+				report(field, "short flag -%s is reserved", short)
+			} else if other, ok := shorts[short]; ok {
+				report(field, "duplicate short flag -%s, already declared by %s", short, other)
+			} else {
+				shorts[short] = field.path
+			}
+		}
+
+		if !isOptionKind(field.Type) {
+			report(field, "unsupported option type %s", field.Type)
+			continue
+		}
+		elemType := field.Type
+		if isVariadicType(elemType) {
+			elemType = elemType.Elem()
+		}
+		c, err := parseConstraints(elemType, tagParts)
+		if err != nil {
+			report(field, "%v", err)
+		}
+		if _, ok := tagParts["required"]; ok {
+			if _, ok := tagParts["default"]; ok {
+				report(field, "required option --%s cannot have a default", optName)
+			}
+		}
+		if defVal, ok := tagParts["default"]; ok {
+			if field.Type.Kind() == reflect.Slice && !isCustomType(field.Type) {
+				report(field, "default= is not supported for slice options")
+			} else if field.Type.Kind() == reflect.Map {
+				report(field, "default= is not supported for map options")
+			} else if value := reflect.New(field.Type).Elem(); setFieldFromString(value, defVal, tagParts["layout"]) != nil {
+				report(field, "invalid default %q for %s", defVal, field.Type)
+			} else if msg := c.rangeViolation(value); msg != "" {
+				report(field, "default %q %s", defVal, msg)
+			}
+		}
+	}
+
+	for _, req := range requirements {
+		for _, name := range req.names {
+			if _, ok := options[name]; !ok {
+				report(req.field, "requires unknown option --%s", name)
+			} else if options[name] == req.field.path {
+				report(req.field, "option --%s requires itself", name)
+			}
+		}
+	}
+	for _, neg := range negatedNames {
+		if other, ok := options[neg.name]; ok {
+			report(neg.field, "negated option --%s is already declared by %s", neg.name, other)
+		}
+	}
+	groupNames := make([]string, 0, len(xorGroups))
+	for group := range xorGroups {
+		groupNames = append(groupNames, group)
+	}
+	sort.Strings(groupNames)
+	for _, group := range groupNames {
+		if fields := xorGroups[group]; len(fields) == 1 {
+			report(fields[0], "xor group %q has no other option", group)
+		}
+	}
+
+	if len(problems) > 0 {
+		return xerrors.Errorf("invalid cli tags: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// unknownTagKeys returns the keys of tagParts missing from known, in sorted order.
+func unknownTagKeys(tagParts map[string]string, known map[string]bool) []string {
+	var unknown []string
+	for key := range tagParts {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// isScalarKind reports whether setFieldFromString can parse a value of type typ.
+func isScalarKind(typ reflect.Type) bool {
+	if typ == durationType || typ == timeType || isCustomType(typ) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isArgumentKind reports whether setArgumentValues can bind positional values to typ.
+func isArgumentKind(typ reflect.Type) bool {
+	if isVariadicType(typ) {
+		typ = typ.Elem()
+	}
+	return isScalarKind(typ) && typ.Kind() != reflect.Bool
+}
+
+// isCountKind reports whether an option of type typ can take the count tag.
+func isCountKind(typ reflect.Type) bool {
+	if typ == durationType || isCustomType(typ) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// isOptionKind reports whether identifyArgumentsAndOptions can register an option of type typ.
+func isOptionKind(typ reflect.Type) bool {
+	if isScalarKind(typ) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Slice:
+		return isScalarKind(typ.Elem())
+	case reflect.Map:
+		key := typ.Key()
+		return key.Kind() == reflect.String && !isCustomType(key) && isScalarKind(typ.Elem())
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── validateCliTags ─────────────────────────────────────────────────────────
+
+func TestValidateCliTags_ValidFixtures(t *testing.T) {
+	for _, obj := range []interface{}{&shipGroup{}, &newShipCmd{}, &moveShipCmd{}, &optArgCmd{}, &envCmd{}, &sliceCmd{}, &quotedTagCmd{}} {
+		if err := validateCliTags(obj); err != nil {
+			t.Errorf("unexpected error for %T: %v", obj, err)
+		}
+	}
+}
+
+func TestValidateCliTags_AggregatesProblems(t *testing.T) {
+	err := validateCliTags(&invalidTagsCmd{})
+	if err == nil {
+		t.Fatal("expected error for invalid tags")
+	}
+	for _, want := range []string{
+		"invalidTagsCmd.Port: unsupported option type complex64",
+		"invalidTagsCmd.IDs: unsupported option type [][]int",
+		`invalidTagsCmd.Speed: invalid default "fast" for int`,
+		`invalidTagsCmd.Dry: unknown key "defualt"`,
+		"invalidTagsCmd.Dry: duplicate short flag -s, already declared by Speed",
+		"invalidTagsCmd.Label: duplicate option --speed, already declared by Speed",
+		`invalidTagsCmd.Name: required argument "name" follows optional argument "color"`,
+		"invalidTagsCmd.verbose: field must be exported",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestValidateCliTags_ReservedAndUnsupported(t *testing.T) {
+	err := validateCliTags(&reservedTagsCmd{})
+	if err == nil {
+		t.Fatal("expected error for reserved options")
+	}
+	for _, want := range []string{
+		`reservedTagsCmd.Parent: unknown key "visible" in parent tag`,
+		"reservedTagsCmd.ShowHelp: option --help is reserved",
+		"reservedTagsCmd.Host: short flag -h is reserved",
+		`reservedTagsCmd.Timeout: invalid default "300" for int8`,
+		"reservedTagsCmd.Tags: default= is not supported for slice options",
+		"reservedTagsCmd.Flag: unsupported argument type bool",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestRegisterCommand_InvalidTags(t *testing.T) {
+	app := New(Args(nil))
+	err := app.RegisterCommand(&invalidTagsCmd{})
+	if err == nil {
+		t.Fatal("expected registration to fail")
+	}
+	if !strings.Contains(err.Error(), "invalidTagsCmd.Port") || !strings.Contains(err.Error(), "invalidTagsCmd.Name") {
+		t.Errorf("expected every problem in one error, got: %v", err)
+	}
+}
+
+func TestValidateCliTags_Variadic(t *testing.T) {
+	type variadicCmd struct {
+		Mode  string   `cli:"argument=mode,default=fast"`
+		Files []string `cli:"argument=files,minargs=2,maxargs=1"`
+		More  []string `cli:"argument=more,default=x"`
+		Port  int      `cli:"option=port,minargs=1"`
+		Host  string   `cli:"option=host,min=1"`
+	}
+	err := validateCliTags(&variadicCmd{})
+	if err == nil {
+		t.Fatal("expected error for variadic tags")
+	}
+	for _, want := range []string{
+		`variadicCmd.Files: variadic argument "files" follows optional argument "mode"`,
+		"variadicCmd.Files: maxargs 1 is less than minargs 2",
+		`variadicCmd.More: variadic argument "more" follows variadic argument "files"`,
+		"variadicCmd.More: default= is not supported for variadic arguments",
+		"variadicCmd.Port: minargs= and maxargs= apply only to variadic arguments",
+		"variadicCmd.Host: min= and max= apply only to numeric fields",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestValidateCliTags_VariadicValueRangeWithoutArity(t *testing.T) {
+	type rangeCmd struct {
+		Files []int `cli:"argument=files,max=3"`
+	}
+	err := validateCliTags(&rangeCmd{})
+	if err == nil || !strings.Contains(err.Error(), `rangeCmd.Files: min= and max= bound the values of variadic argument "files", not their number: use minargs= and maxargs=`) {
+		t.Errorf("expected min=/max= without arity to be rejected, got: %v", err)
+	}
+
+	type arityCmd struct {
+		Files []int `cli:"argument=files,minargs=1,max=3"`
+	}
+	if err := validateCliTags(&arityCmd{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateCliTags_RequiredOptionWithDefault(t *testing.T) {
+	type requiredDefaultCmd struct {
+		Port int `cli:"option=port,required,default=80"`
+	}
+	err := validateCliTags(&requiredDefaultCmd{})
+	if err == nil || !strings.Contains(err.Error(), "required option --port cannot have a default") {
+		t.Errorf("expected required with default error, got: %v", err)
+	}
+}
+
+func TestValidateCliTags_NegatableAndCount(t *testing.T) {
+	type badFlagsCmd struct {
+		Name    string  `cli:"argument=name,count"`
+		Cache   string  `cli:"option=cache,negatable"`
+		Color   bool    `cli:"option=color,negatable"`
+		NoColor bool    `cli:"option=no-color"`
+		Level   float64 `cli:"option=level,count"`
+		Verbose int     `cli:"option=loud,count,default=1"`
+	}
+	err := validateCliTags(&badFlagsCmd{})
+	if err == nil {
+		t.Fatal("expected error for negatable and count tags")
+	}
+	for _, want := range []string{
+		"badFlagsCmd.Name: count applies only to options",
+		"badFlagsCmd.Cache: negatable applies only to bool options",
+		"badFlagsCmd.Color: negated option --no-color is already declared by NoColor",
+		"badFlagsCmd.Level: count applies only to int options",
+		"badFlagsCmd.Verbose: default= is not supported for count options",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestValidateCliTags_MapOptions(t *testing.T) {
+	type badMapCmd struct {
+		Labels map[string]string `cli:"option=label,default=a=b"`
+		Limits map[string]int    `cli:"option=limit,max=10"`
+		ByID   map[int]string    `cli:"option=by-id"`
+		Nested map[string][]int  `cli:"option=nested"`
+	}
+	err := validateCliTags(&badMapCmd{})
+	if err == nil {
+		t.Fatal("expected error for map options")
+	}
+	for _, want := range []string{
+		"badMapCmd.Labels: default= is not supported for map options",
+		"badMapCmd.Limits: max= is not supported for map options",
+		"badMapCmd.ByID: unsupported option type map[int]string",
+		"badMapCmd.Nested: unsupported option type map[string][]int",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
@@ -8,10 +8,15 @@ package cligo
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/xerrors"
 )
@@ -53,6 +58,14 @@ func writeTempFile(t *testing.T, name, content string) string {
 	return path
 }
 
+// writeFile writes a file at path, in a directory the test created.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+}
+
 // ─── fixtures ────────────────────────────────────────────────────────────────
 
 // shipGroup is a top-level group registered under the "cli" root.
@@ -329,3 +342,392 @@ type sliceEnvCmd struct {
 func (c *sliceEnvCmd) Command() string             { return "sliceenvcmd" }
 func (c *sliceEnvCmd) Help() (string, string)      { return "Slice env command.", "" }
 func (c *sliceEnvCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// deployCmd implements CliCompleter, completing its argument and --env option dynamically.
+type deployCmd struct {
+	Parent  CliGroup `cli:"group=cli"`
+	Service string   `cli:"argument=service"`
+	Env     string   `cli:"option=env,short=e,help=Target environment"`
+	Force   bool     `cli:"option=force,help=Force deploy"`
+}
+
+func (c *deployCmd) Command() string             { return "deploy" }
+func (c *deployCmd) Help() (string, string)      { return "Deploy a service.", "" }
+func (c *deployCmd) Run(_ context.Context) error { return nil }
+func (c *deployCmd) Complete(_ context.Context, argName string, partial string) []string {
+	var values []string
+	switch argName {
+	case "service":
+		values = []string{"api", "web", "worker"}
+	case "env":
+		values = []string{"prod", "staging"}
+	}
+	var candidates []string
+	for _, v := range values {
+		if strings.HasPrefix(v, partial) {
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates
+}
+
+// quotedTagCmd has tag values containing commas.
+type quotedTagCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Speed  int      `cli:"option=speed,help='Speed, in knots',default=10"`
+	Tags   []string `cli:"option=tag,env=QUOTED_TAGS,help=Tags\\, comma-separated in env"`
+	ran    bool
+}
+
+func (c *quotedTagCmd) Command() string             { return "quoted" }
+func (c *quotedTagCmd) Help() (string, string)      { return "Quoted tag test.", "" }
+func (c *quotedTagCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// badTagCmd has an unquoted comma inside its help text.
+type badTagCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Speed  int      `cli:"option=speed,help=Speed, in knots"`
+}
+
+func (c *badTagCmd) Command() string             { return "badtag" }
+func (c *badTagCmd) Help() (string, string)      { return "Bad tag test.", "" }
+func (c *badTagCmd) Run(_ context.Context) error { return nil }
+
+// invalidTagsCmd collects one mistake of each kind reported by validateCliTags.
+type invalidTagsCmd struct {
+	Parent  CliGroup  `cli:"group=cli"`
+	Port    complex64 `cli:"option=port,help=Port"`
+	IDs     [][]int   `cli:"option=id,help=IDs"`
+	Speed   int       `cli:"option=speed,short=s,default=fast"`
+	Dry     bool      `cli:"option=dry,short=s,defualt=true"`
+	Label   string    `cli:"option=speed"`
+	Color   string    `cli:"argument=color,default=blue"`
+	Name    string    `cli:"argument=name"`
+	verbose bool      `cli:"option=quiet"`
+}
+
+func (c *invalidTagsCmd) Command() string             { return "invalid" }
+func (c *invalidTagsCmd) Help() (string, string)      { return "Invalid tags test.", "" }
+func (c *invalidTagsCmd) Run(_ context.Context) error { return nil }
+
+// reservedTagsCmd redeclares the options every command registers itself.
+type reservedTagsCmd struct {
+	Parent   CliGroup `cli:"group=cli,visible"`
+	ShowHelp bool     `cli:"option=help"`
+	Host     string   `cli:"option=host,short=h"`
+	Timeout  int8     `cli:"option=timeout,default=300"`
+	Tags     []string `cli:"option=tag,default=a"`
+	Flag     bool     `cli:"argument=flag"`
+}
+
+func (c *reservedTagsCmd) Command() string             { return "reserved" }
+func (c *reservedTagsCmd) Help() (string, string)      { return "Reserved tags test.", "" }
+func (c *reservedTagsCmd) Run(_ context.Context) error { return nil }
+
+// logLevel is an enum parsed through encoding.TextUnmarshaler.
+type logLevel int
+
+func (l *logLevel) UnmarshalText(text []byte) error {
+	for i, name := range []string{"debug", "info", "warn"} {
+		if string(text) == name {
+			*l = logLevel(i)
+			return nil
+		}
+	}
+	return xerrors.Errorf("unknown level %q", text)
+}
+
+// semver is a version parsed through pflag.Value.
+type semver struct {
+	major, minor int
+}
+
+func (v *semver) Set(s string) error {
+	_, err := fmt.Sscanf(s, "%d.%d", &v.major, &v.minor)
+	return err
+}
+func (v *semver) String() string { return fmt.Sprintf("%d.%d", v.major, v.minor) }
+func (v *semver) Type() string   { return "semver" }
+
+// customTypesCmd binds custom types to arguments and options.
+type customTypesCmd struct {
+	Parent   CliGroup `cli:"group=cli"`
+	Version  semver   `cli:"argument=version"`
+	Level    logLevel `cli:"option=level,default=info,env=CUSTOM_LEVEL,help=Log level"`
+	Addr     net.IP   `cli:"option=addr,default=127.0.0.1,help=Listen address"`
+	Endpoint *url.URL `cli:"option=endpoint,help=Endpoint URL"`
+	ran      bool
+}
+
+func (c *customTypesCmd) Command() string             { return "custom" }
+func (c *customTypesCmd) Help() (string, string)      { return "Custom types test.", "" }
+func (c *customTypesCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// timeUintCmd binds durations, times, unsigned integers and int64 slices.
+type timeUintCmd struct {
+	Parent  CliGroup        `cli:"group=cli"`
+	Retries uint8           `cli:"argument=retries,default=3"`
+	Timeout time.Duration   `cli:"option=timeout,default=30s,env=TU_TIMEOUT,help=Timeout"`
+	Since   time.Time       `cli:"option=since,help=Start date"`
+	Until   time.Time       `cli:"option=until,layout=02/01/2006,help=End date"`
+	Port    uint16          `cli:"option=port,default=8080,help=Port"`
+	IDs     []int64         `cli:"option=id,env=TU_IDS,help=IDs"`
+	Delays  []time.Duration `cli:"option=delay,help=Delays"`
+	ran     bool
+}
+
+func (c *timeUintCmd) Command() string             { return "timeuint" }
+func (c *timeUintCmd) Help() (string, string)      { return "Time and uint test.", "" }
+func (c *timeUintCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// copyCmd has a variadic argument followed by a required one, like cp SRC... DST.
+type copyCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Src    []string `cli:"argument=src,help=Source files"`
+	Dst    string   `cli:"argument=dst,help=Destination"`
+	ran    bool
+}
+
+func (c *copyCmd) Command() string             { return "cp" }
+func (c *copyCmd) Help() (string, string)      { return "Copy test.", "" }
+func (c *copyCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// sumCmd has an optional variadic integer argument with an upper bound.
+type sumCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Op     string   `cli:"argument=op"`
+	Nums   []int64  `cli:"argument=nums,minargs=0,maxargs=3,help=Numbers"`
+	ran    bool
+}
+
+func (c *sumCmd) Command() string             { return "sum" }
+func (c *sumCmd) Help() (string, string)      { return "Sum test.", "" }
+func (c *sumCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// portsCmd has a variadic integer argument with both an arity and a value range.
+type portsCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Ports  []int    `cli:"argument=ports,maxargs=3,min=1,max=65535,help=Ports to open"`
+	ran    bool
+}
+
+func (c *portsCmd) Command() string             { return "open" }
+func (c *portsCmd) Help() (string, string)      { return "Variadic range test.", "" }
+func (c *portsCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// requiredOptCmd has required options, one with an environment fallback.
+type requiredOptCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Token  string   `cli:"option=token,required,env=REQ_TOKEN,help=API token"`
+	User   string   `cli:"option=user,required,help=User name"`
+	Port   int      `cli:"option=port,default=80,help=Port"`
+	ran    bool
+}
+
+func (c *requiredOptCmd) Command() string             { return "login" }
+func (c *requiredOptCmd) Help() (string, string)      { return "Required options test.", "" }
+func (c *requiredOptCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// choicesCmd restricts an argument and options to enumerated values.
+type choicesCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Mode   string   `cli:"argument=mode,choices=fast|safe"`
+	Format string   `cli:"option=format,default=table,choices=json|yaml|table,env=CHOICES_FORMAT,help=Output format"`
+	Level  []int    `cli:"option=level,choices=1|2|3,help=Levels"`
+	ran    bool
+}
+
+func (c *choicesCmd) Command() string             { return "export" }
+func (c *choicesCmd) Help() (string, string)      { return "Choices test.", "" }
+func (c *choicesCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// constrainedCmd declares min=, max=, pattern= and exists= constraints.
+type constrainedCmd struct {
+	Parent  CliGroup      `cli:"group=cli"`
+	Name    string        `cli:"argument=name,pattern=^[a-z]+$"`
+	Files   []string      `cli:"argument=files,minargs=0,exists=file"`
+	Port    int           `cli:"option=port,min=1,max=65535,env=CONSTRAINED_PORT,help=Port"`
+	Ratio   float64       `cli:"option=ratio,min=0,max=1,default=0.5,help=Ratio"`
+	Timeout time.Duration `cli:"option=timeout,min=1s,help=Timeout"`
+	Dir     string        `cli:"option=dir,exists=dir,help=Work directory"`
+	Weights []uint        `cli:"option=weight,max=10,help=Weights"`
+	ran     bool
+}
+
+func (c *constrainedCmd) Command() string             { return "serve" }
+func (c *constrainedCmd) Help() (string, string)      { return "Constraints test.", "" }
+func (c *constrainedCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// windowCmd checks that --from is before --to in Validate.
+type windowCmd struct {
+	Parent CliGroup  `cli:"group=cli"`
+	From   time.Time `cli:"option=from,help=Start"`
+	To     time.Time `cli:"option=to,help=End"`
+	ran    bool
+}
+
+func (c *windowCmd) Command() string        { return "window" }
+func (c *windowCmd) Help() (string, string) { return "Validate test.", "" }
+func (c *windowCmd) Validate(_ context.Context) error {
+	if !c.From.IsZero() && !c.To.IsZero() && !c.From.Before(c.To) {
+		return xerrors.New("--from must be before --to")
+	}
+	return nil
+}
+func (c *windowCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// sourceCmd declares mutually exclusive and co-required options.
+type sourceCmd struct {
+	Parent   CliGroup `cli:"group=cli"`
+	File     string   `cli:"option=file,xor=source,help=Read from file"`
+	Stdin    bool     `cli:"option=stdin,xor=source,help=Read from stdin"`
+	URL      string   `cli:"option=url,xor=source,help=Read from URL"`
+	User     string   `cli:"option=user,requires=password,help=User name"`
+	Password string   `cli:"option=password,env=SOURCE_PASSWORD,help=Password"`
+	ran      bool
+}
+
+func (c *sourceCmd) Command() string             { return "import" }
+func (c *sourceCmd) Help() (string, string)      { return "Option relations test.", "" }
+func (c *sourceCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// buildFlagsCmd declares a negatable bool option and a counted int option.
+type buildFlagsCmd struct {
+	Parent    CliGroup `cli:"group=cli"`
+	Cache     bool     `cli:"option=cache,negatable,default=true,env=BUILD_CACHE,help=Use the build cache"`
+	Verbosity int      `cli:"option=verbosity,short=-v,count,help=Increase verbosity"`
+	ran       bool
+}
+
+func (c *buildFlagsCmd) Command() string             { return "build" }
+func (c *buildFlagsCmd) Help() (string, string)      { return "Negatable and count test.", "" }
+func (c *buildFlagsCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// mapOptionsCmd declares map-valued options.
+type mapOptionsCmd struct {
+	Parent CliGroup          `cli:"group=cli"`
+	Labels map[string]string `cli:"option=label,short=-l,env=DEPLOY_LABELS,help=Add a label"`
+	Limits map[string]int    `cli:"option=limit,env=DEPLOY_LIMITS,help=Resource limits"`
+}
+
+func (c *mapOptionsCmd) Command() string             { return "rollout" }
+func (c *mapOptionsCmd) Help() (string, string)      { return "Map options test.", "" }
+func (c *mapOptionsCmd) Run(_ context.Context) error { return nil }
+
+// dbOptions is a reusable option set embedded with prefixes.
+type dbOptions struct {
+	Host string `cli:"option=host,default=localhost,env=HOST,help=Database host"`
+	Port int    `cli:"option=port,default=5432,min=1,env=PORT,help=Database port"`
+	User string `cli:"option=user,requires=password,help=Database user"`
+	Pass string `cli:"option=password,env=PASSWORD,help=Database password"`
+}
+
+// tlsOptions is nested inside cacheOptions to check that prefixes add up.
+type tlsOptions struct {
+	Cert string `cli:"option=cert,env=CERT,help=Certificate file"`
+}
+
+type cacheOptions struct {
+	Addr string     `cli:"option=addr,help=Cache address"`
+	TLS  tlsOptions `cli:"embed,prefix=tls-,envprefix=TLS_"`
+}
+
+// migrateCmd embeds option sets: dbOptions anonymously, cacheOptions as a named field.
+type migrateCmd struct {
+	Parent    CliGroup `cli:"group=cli"`
+	dbOptions `cli:"embed,prefix=db-,envprefix=DB_,help=Database options"`
+	Cache     cacheOptions `cli:"embed,prefix=cache-,envprefix=CACHE_"`
+	Target    string       `cli:"argument=target,default=latest"`
+	DryRun    bool         `cli:"option=dry-run,help=Print the plan only"`
+}
+
+func (c *migrateCmd) Command() string             { return "migrate" }
+func (c *migrateCmd) Help() (string, string)      { return "Embedded options test.", "" }
+func (c *migrateCmd) Run(_ context.Context) error { return nil }
+
+// cloudGroup declares a persistent option inherited by every command below it.
+type cloudGroup struct {
+	Parent CliGroup `cli:"group=cli"`
+	Region string   `cli:"option=region,short=-r,default=us-east-1,env=CLOUD_REGION,help=Cloud region"`
+}
+
+func (g *cloudGroup) Group() string          { return "cloud" }
+func (g *cloudGroup) Help() (string, string) { return "Manage the cloud.", "" }
+
+// cloudNodesGroup is nested under cloudGroup and adds an option of its own.
+type cloudNodesGroup struct {
+	Parent CliGroup `cli:"group=cloud"`
+	Zone   string   `cli:"option=zone,help=Availability zone"`
+}
+
+func (g *cloudNodesGroup) Group() string          { return "nodes" }
+func (g *cloudNodesGroup) Help() (string, string) { return "Manage nodes.", "" }
+
+// scaleNodesCmd sits below both cloud groups and declares -p itself.
+type scaleNodesCmd struct {
+	Parent CliGroup `cli:"group=nodes"`
+	Count  int      `cli:"argument=count"`
+	Port   int      `cli:"option=port,short=-p,default=22,help=Node port"`
+	ran    bool
+}
+
+func (c *scaleNodesCmd) Command() string             { return "scale" }
+func (c *scaleNodesCmd) Help() (string, string)      { return "Group options test.", "" }
+func (c *scaleNodesCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// regionCmd declares an option its group already declares.
+type regionCmd struct {
+	Parent CliGroup `cli:"group=cloud"`
+	Region string   `cli:"option=region"`
+}
+
+func (c *regionCmd) Command() string             { return "region" }
+func (c *regionCmd) Help() (string, string)      { return "Conflicting option test.", "" }
+func (c *regionCmd) Run(_ context.Context) error { return nil }
+
+// argGroup declares an argument, which groups do not support.
+type argGroup struct {
+	Parent CliGroup `cli:"group=cli"`
+	Name   string   `cli:"argument=name"`
+}
+
+func (g *argGroup) Group() string          { return "named" }
+func (g *argGroup) Help() (string, string) { return "Group argument test.", "" }
+
+// listenCmd binds options to properties as their fallback source.
+type listenCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Host   string   `cli:"option=host,required,property=server.host,help=Bind host"`
+	Port   int      `cli:"option=port,default=80,env=LISTEN_PORT,property=server.port,help=Bind port"`
+	Tags   []string `cli:"option=tag,property=server.tags"`
+	ran    bool
+}
+
+func (c *listenCmd) Command() string             { return "listen" }
+func (c *listenCmd) Help() (string, string)      { return "Property binding test.", "" }
+func (c *listenCmd) Run(_ context.Context) error { c.ran = true; return nil }
+
+// badPropertyCmd misuses the property= tag.
+type badPropertyCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Name   string   `cli:"argument=name,property=app.name"`
+	Port   int      `cli:"option=port,property"`
+}
+
+func (c *badPropertyCmd) Command() string             { return "badproperty" }
+func (c *badPropertyCmd) Help() (string, string)      { return "Invalid property= test.", "" }
+func (c *badPropertyCmd) Run(_ context.Context) error { return nil }
+
+// cacheDirCmd has placeholders in its default= and help= values.
+type cacheDirCmd struct {
+	Parent CliGroup `cli:"group=cli"`
+	Dir    string   `cli:"option=dir,default=${app.home}/cache,help=Cache directory"`
+	Port   int      `cli:"option=port,default=${server.port:8080},help=Port (default ${server.port:8080})"`
+	User   string   `cli:"option=user,default=${CACHE_USER:nobody}"`
+	Tmpl   string   `cli:"option=template,help=Output template; may use $${USER}"`
+	ran    bool
+}
+
+func (c *cacheDirCmd) Command() string             { return "cachedir" }
+func (c *cacheDirCmd) Help() (string, string)      { return "Placeholder test.", "" }
+func (c *cacheDirCmd) Run(_ context.Context) error { c.ran = true; return nil }
@@ -32,7 +32,12 @@ func (t *implCliApplication) getCommandUsage(cmd CliCommand, stack []string) str
 			name := strings.ToUpper(argName)
 			_, hasDefault := tagParts["default"]
 			_, hasRequired := tagParts["required"]
-			if hasDefault && !hasRequired {
+			if isVariadicType(field.Type) {
+				if min, _, _ := parseArity(tagParts); min == 0 {
+					name = "[" + name + "]"
+				}
+				name = name + "..."
+			} else if hasDefault && !hasRequired {
 				name = "[" + name + "]"
 			}
 			arguments = append(arguments, name)
@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/xerrors"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	pflagValueType      = reflect.TypeOf((*pflag.Value)(nil)).Elem()
+	urlType             = reflect.TypeOf(url.URL{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// timeLayouts are tried in order to parse time values of fields without a layout= tag.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTime parses value with layout, or with the first of timeLayouts that matches when layout is empty.
+func parseTime(value string, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, value)
+	}
+	var firstErr error
+	for _, l := range timeLayouts {
+		val, err := time.Parse(l, value)
+		if err == nil {
+			return val, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// isCustomType reports whether values of typ are parsed by the type itself: types whose
+// pointer implements encoding.TextUnmarshaler or pflag.Value, pointers to such types, and url.URL.
+// time.Time is handled by setFieldFromString itself to honour layout= tags.
+func isCustomType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == timeType {
+		return false
+	}
+	ptr := reflect.PtrTo(typ)
+	return typ == urlType || ptr.Implements(textUnmarshalerType) || ptr.Implements(pflagValueType)
+}
+
+// customTypeName names a custom type in help output and errors: the Type() of a pflag.Value,
+// otherwise the lower-cased Go type name (ip, url, level).
+func customTypeName(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if v, ok := reflect.New(typ).Interface().(pflag.Value); ok {
+		return v.Type()
+	}
+	return strings.ToLower(typ.Name())
+}
+
+// setCustomFromString parses value with the custom type's own parser and sets field,
+// allocating the value for pointer fields. The field is left unchanged on error.
+func setCustomFromString(field reflect.Value, value string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	target := reflect.New(typ)
+
+	var err error
+	switch v := target.Interface().(type) {
+	case *url.URL:
+		var u *url.URL
+		if u, err = url.Parse(value); err == nil {
+			*v = *u
+		}
+	case encoding.TextUnmarshaler:
+		err = v.UnmarshalText([]byte(value))
+	case pflag.Value:
+		err = v.Set(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	if field.Kind() == reflect.Ptr {
+		field.Set(target)
+	} else {
+		field.Set(target.Elem())
+	}
+	return nil
+}
+
+// isTextValueType reports whether an option of type typ is registered as a textValue
+// instead of one of the typed pflag flags.
+func isTextValueType(typ reflect.Type) bool {
+	if typ == durationType || typ == timeType || isCustomType(typ) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// textValue is the pflag.Value registered for options of durations, times, unsigned integers
+// and custom types. It keeps the raw text, checked to parse into the field type, and
+// setOptionValues binds it to the field.
+type textValue struct {
+	typ    reflect.Type
+	layout string
+	value  string
+}
+
+func (v *textValue) Set(s string) error {
+	if err := setFieldFromString(reflect.New(v.typ).Elem(), s, v.layout); err != nil {
+		return err
+	}
+	v.value = s
+	return nil
+}
+
+func (v *textValue) String() string {
+	return v.value
+}
+
+func (v *textValue) Type() string {
+	return valueTypeName(v.typ)
+}
+
+// sliceValue is the pflag.Value registered for slice options. Every use of the flag appends
+// to values, and the text of each element to raw; elements other than strings and custom
+// types may also be comma-separated (--port=80,443).
+type sliceValue struct {
+	values reflect.Value
+	raw    []string
+	layout string
+}
+
+func (v *sliceValue) Set(s string) error {
+	elemType := v.values.Type().Elem()
+	parts := []string{s}
+	if elemType.Kind() != reflect.String && !isCustomType(elemType) {
+		parts = strings.Split(s, ",")
+	}
+	for _, p := range parts {
+		elem := reflect.New(elemType).Elem()
+		if err := setFieldFromString(elem, p, v.layout); err != nil {
+			return err
+		}
+		v.values = reflect.Append(v.values, elem)
+		v.raw = append(v.raw, p)
+	}
+	return nil
+}
+
+func (v *sliceValue) String() string {
+	parts := make([]string, v.values.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.values.Index(i).Interface())
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func (v *sliceValue) Type() string {
+	return valueTypeName(v.values.Type().Elem()) + "Slice"
+}
+
+// mapValue is the pflag.Value registered for map options. Every use of the flag adds a key=value
+// entry; entries with values other than strings and custom types may also be comma-separated
+// (--limit=cpu=2,mem=4). A later entry for the same key replaces the earlier one.
+type mapValue struct {
+	values reflect.Value
+	layout string
+}
+
+func (v *mapValue) Set(s string) error {
+	elemType := v.values.Type().Elem()
+	entries := []string{s}
+	if elemType.Kind() != reflect.String && !isCustomType(elemType) {
+		entries = strings.Split(s, ",")
+	}
+	for _, entry := range entries {
+		if err := setMapEntry(v.values, entry, v.layout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *mapValue) String() string {
+	keys := make([]string, 0, v.values.Len())
+	for _, key := range v.values.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		keys[i] = key + "=" + fmt.Sprint(v.values.MapIndex(reflect.ValueOf(key).Convert(v.values.Type().Key())).Interface())
+	}
+	return "[" + strings.Join(keys, ",") + "]"
+}
+
+func (v *mapValue) Type() string {
+	return valueTypeName(v.values.Type().Elem()) + "Map"
+}
+
+// setMapEntry parses one key=value entry into the map m. The key must not be empty.
+func setMapEntry(m reflect.Value, entry string, layout string) error {
+	key, value, ok := strings.Cut(entry, "=")
+	if !ok || key == "" {
+		return xerrors.Errorf("expected key=value, got %q", entry)
+	}
+	elem := reflect.New(m.Type().Elem()).Elem()
+	if err := setFieldFromString(elem, value, layout); err != nil {
+		return err
+	}
+	m.SetMapIndex(reflect.ValueOf(key).Convert(m.Type().Key()), elem)
+	return nil
+}
+
+// negatedValue is the pflag.Value registered as --no-<name> for negatable bool options. It sets
+// the option itself to the opposite value, so the option counts as given and the last of
+// --name and --no-name wins.
+type negatedValue struct {
+	flagSet *pflag.FlagSet
+	name    string
+}
+
+func (v *negatedValue) Set(s string) error {
+	val, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	return v.flagSet.Set(v.name, strconv.FormatBool(!val))
+}
+
+func (v *negatedValue) String() string {
+	return "false"
+}
+
+func (v *negatedValue) Type() string {
+	return "bool"
+}
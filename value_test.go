@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2026 Karagatan LLC.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+package cligo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── custom types ────────────────────────────────────────────────────────────
+
+func TestCustomTypes_FlagsAndArgument(t *testing.T) {
+	cmd := &customTypesCmd{}
+	err := Run(Args([]string{"custom", "1.2", "--level=warn", "--addr=10.0.0.1", "--endpoint=https://example.com/api"}), Beans(cmd))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Version != (semver{major: 1, minor: 2}) {
+		t.Errorf("expected Version=1.2, got %v", cmd.Version.String())
+	}
+	if cmd.Level != 2 {
+		t.Errorf("expected Level=warn, got %d", cmd.Level)
+	}
+	if cmd.Addr.String() != "10.0.0.1" {
+		t.Errorf("expected Addr=10.0.0.1, got %v", cmd.Addr)
+	}
+	if cmd.Endpoint == nil || cmd.Endpoint.Host != "example.com" {
+		t.Errorf("expected Endpoint host example.com, got %v", cmd.Endpoint)
+	}
+}
+
+func TestCustomTypes_DefaultsAndEnv(t *testing.T) {
+	t.Setenv("CUSTOM_LEVEL", "debug")
+	cmd := &customTypesCmd{Level: 1}
+	if err := Run(Args([]string{"custom", "0.1"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Level != 0 {
+		t.Errorf("expected Level=debug from env, got %d", cmd.Level)
+	}
+	if cmd.Addr.String() != "127.0.0.1" {
+		t.Errorf("expected Addr=127.0.0.1 from default, got %v", cmd.Addr)
+	}
+	if cmd.Endpoint != nil {
+		t.Errorf("expected nil Endpoint without flag or default, got %v", cmd.Endpoint)
+	}
+}
+
+func TestCustomTypes_InvalidValues(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"custom", "x"}, "invalid semver for argument version: x"},
+		{[]string{"custom", "1.0", "--level=loud"}, `invalid argument "loud" for "--level" flag`},
+	} {
+		err := Run(Args(tc.args), Stdout(&bytes.Buffer{}), Stderr(&bytes.Buffer{}), Beans(&customTypesCmd{}))
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%v: expected usage error, got: %v", tc.args, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: expected %q in error, got: %v", tc.args, tc.want, err)
+		}
+	}
+}
+
+func TestCustomTypes_InvalidEnv(t *testing.T) {
+	t.Setenv("CUSTOM_LEVEL", "loud")
+	err := Run(Args([]string{"custom", "1.0"}), Stdout(&bytes.Buffer{}), Beans(&customTypesCmd{}))
+	if err == nil || !strings.Contains(err.Error(), "invalid loglevel for option --level from $CUSTOM_LEVEL: loud") {
+		t.Errorf("expected env parse error, got: %v", err)
+	}
+}
+
+func TestCustomTypes_HelpShowsTypeName(t *testing.T) {
+	var out bytes.Buffer
+	if err := Run(Args([]string{"custom", "--help"}), Stdout(&out), Beans(&customTypesCmd{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"VERSION semver", "--level loglevel", "--addr ip", "--endpoint url"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in help, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestValidateCliTags_CustomDefault(t *testing.T) {
+	type badDefaultCmd struct {
+		Level logLevel `cli:"option=level,default=loud"`
+	}
+	err := validateCliTags(&badDefaultCmd{})
+	if err == nil || !strings.Contains(err.Error(), `invalid default "loud"`) {
+		t.Errorf("expected invalid default error, got: %v", err)
+	}
+}
+
+// ─── durations, times, unsigned integers ─────────────────────────────────────
+
+func TestTimeUint_Flags(t *testing.T) {
+	cmd := &timeUintCmd{}
+	args := []string{"timeuint", "7", "--timeout=1m30s", "--since=2024-01-01", "--until=31/12/2024",
+		"--port=443", "--id=1", "--id=9007199254740993,2", "--delay=1s,2s"}
+	if err := Run(Args(args), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Retries != 7 {
+		t.Errorf("expected Retries=7, got %d", cmd.Retries)
+	}
+	if cmd.Timeout != 90*time.Second {
+		t.Errorf("expected Timeout=1m30s, got %v", cmd.Timeout)
+	}
+	if !cmd.Since.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Since=2024-01-01, got %v", cmd.Since)
+	}
+	if !cmd.Until.Equal(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Until=2024-12-31, got %v", cmd.Until)
+	}
+	if cmd.Port != 443 {
+		t.Errorf("expected Port=443, got %d", cmd.Port)
+	}
+	if len(cmd.IDs) != 3 || cmd.IDs[1] != 9007199254740993 || cmd.IDs[2] != 2 {
+		t.Errorf("expected IDs=[1 9007199254740993 2], got %v", cmd.IDs)
+	}
+	if len(cmd.Delays) != 2 || cmd.Delays[1] != 2*time.Second {
+		t.Errorf("expected Delays=[1s 2s], got %v", cmd.Delays)
+	}
+}
+
+func TestTimeUint_DefaultsAndEnv(t *testing.T) {
+	t.Setenv("TU_TIMEOUT", "5s")
+	t.Setenv("TU_IDS", "10, 20")
+	cmd := &timeUintCmd{}
+	if err := Run(Args([]string{"timeuint"}), Beans(cmd)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.Retries != 3 || cmd.Port != 8080 {
+		t.Errorf("expected defaults Retries=3 Port=8080, got %d %d", cmd.Retries, cmd.Port)
+	}
+	if cmd.Timeout != 5*time.Second {
+		t.Errorf("expected Timeout=5s from env, got %v", cmd.Timeout)
+	}
+	if !cmd.Since.IsZero() {
+		t.Errorf("expected zero Since, got %v", cmd.Since)
+	}
+	if len(cmd.IDs) != 2 || cmd.IDs[0] != 10 || cmd.IDs[1] != 20 {
+		t.Errorf("expected IDs=[10 20] from env, got %v", cmd.IDs)
+	}
+}
+
+func TestTimeUint_InvalidValues(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		env  string
+		want string
+	}{
+		{[]string{"timeuint", "300"}, "", "invalid unsigned integer for argument retries: 300"},
+		{[]string{"timeuint", "--port=70000"}, "", `invalid argument "70000" for "--port" flag`},
+		{[]string{"timeuint", "--until=2024-12-31"}, "", `invalid argument "2024-12-31" for "--until" flag`},
+		{[]string{"timeuint"}, "30", "invalid duration for option --timeout from $TU_TIMEOUT: 30"},
+	} {
+		t.Setenv("TU_TIMEOUT", tc.env)
+		err := Run(Args(tc.args), Stdout(&bytes.Buffer{}), Stderr(&bytes.Buffer{}), Beans(&timeUintCmd{}))
+		if ExitCode(err) != ExitCodeUsage {
+			t.Errorf("%v: expected usage error, got: %v", tc.args, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: expected %q in error, got: %v", tc.args, tc.want, err)
+		}
+	}
+}
+
+func TestValidateCliTags_Layout(t *testing.T) {
+	type layoutCmd struct {
+		Name  string    `cli:"option=name,layout=2006"`
+		Since time.Time `cli:"option=since,layout=2006-01-02,default=yesterday"`
+	}
+	err := validateCliTags(&layoutCmd{})
+	if err == nil {
+		t.Fatal("expected error for layout tags")
+	}
+	for _, want := range []string{"layoutCmd.Name: layout= applies only to time.Time fields", `layoutCmd.Since: invalid default "yesterday"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}